@@ -0,0 +1,752 @@
+package garabic
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+//Direction is the base direction of a paragraph
+type Direction int
+
+const (
+	//AutoDirection takes the direction of the first strong letter in the paragraph
+	AutoDirection Direction = iota
+	//LeftToRight paragraphs are laid out from the left
+	LeftToRight
+	//RightToLeft paragraphs are laid out from the right
+	RightToLeft
+)
+
+//maxBidiDepth is the maximum explicit embedding level (BD2)
+const maxBidiDepth = 125
+
+//maxBracketPairs is the maximum depth of the bracket pairs stack (BD16)
+const maxBracketPairs = 63
+
+//bidiParagraph holds a paragraph resolved by the Unicode Bidirectional Algorithm (UAX #9)
+// https://www.unicode.org/reports/tr9/
+type bidiParagraph struct {
+	runes []rune
+	//initialTypes are the bidi classes of the runes
+	initialTypes []bidi.Class
+	//types are the resolved bidi classes of the runes
+	types []bidi.Class
+	//levels are the resolved embedding levels of the runes
+	levels []int
+	//explicitLevels are the levels of the runes after the explicit embeddings, before the implicit levels
+	explicitLevels []int
+	//level is the paragraph embedding level
+	level int
+	//matchingPDI and matchingInitiator link isolate initiators with their PDI, -1 if unmatched
+	matchingPDI       []int
+	matchingInitiator []int
+}
+
+//directionalStatus is an entry of the directional status stack (X1)
+type directionalStatus struct {
+	level    int
+	override bidi.Class
+	isolate  bool
+}
+
+//newBidiParagraph resolves the embedding levels of a paragraph, runes should not contain a paragraph separator
+func newBidiParagraph(runes []rune, dir Direction) *bidiParagraph {
+	p := &bidiParagraph{
+		runes:             runes,
+		initialTypes:      make([]bidi.Class, len(runes)),
+		types:             make([]bidi.Class, len(runes)),
+		levels:            make([]int, len(runes)),
+		matchingPDI:       make([]int, len(runes)),
+		matchingInitiator: make([]int, len(runes)),
+	}
+	for i, r := range runes {
+		p.initialTypes[i] = bidiClass(r)
+	}
+	copy(p.types, p.initialTypes)
+	p.matchIsolates()
+
+	switch dir {
+	case LeftToRight:
+		p.level = 0
+	case RightToLeft:
+		p.level = 1
+	default:
+		p.level = p.firstStrongLevel(0, len(runes), 0)
+	}
+
+	p.resolveExplicitLevels()
+	//The sequences overwrite the levels, their start and end types are taken from the explicit levels
+	p.explicitLevels = append([]int(nil), p.levels...)
+	for _, sequence := range p.isolatingRunSequences() {
+		p.resolveSequence(sequence)
+	}
+	//Removed characters take the level of the preceding character
+	for i := range p.runes {
+		if isRemovedByX9(p.initialTypes[i]) {
+			if i == 0 {
+				p.levels[i] = p.level
+			} else {
+				p.levels[i] = p.levels[i-1]
+			}
+		}
+	}
+	return p
+}
+
+//bidiClass returns the bidi class of a rune
+func bidiClass(r rune) bidi.Class {
+	props, _ := bidi.LookupRune(r)
+	return props.Class()
+}
+
+//matchIsolates links isolate initiators and PDIs (BD9)
+func (p *bidiParagraph) matchIsolates() {
+	var openers []int
+	for i, t := range p.initialTypes {
+		p.matchingPDI[i] = -1
+		p.matchingInitiator[i] = -1
+		switch t {
+		case bidi.LRI, bidi.RLI, bidi.FSI:
+			openers = append(openers, i)
+		case bidi.PDI:
+			if len(openers) > 0 {
+				opener := openers[len(openers)-1]
+				openers = openers[:len(openers)-1]
+				p.matchingPDI[opener] = i
+				p.matchingInitiator[i] = opener
+			}
+		}
+	}
+}
+
+//firstStrongLevel finds the level of the first strong letter between start and end skipping isolates (P2, P3)
+func (p *bidiParagraph) firstStrongLevel(start, end, fallback int) int {
+	for i := start; i < end; i++ {
+		switch p.initialTypes[i] {
+		case bidi.L:
+			return 0
+		case bidi.R, bidi.AL:
+			return 1
+		case bidi.LRI, bidi.RLI, bidi.FSI:
+			if p.matchingPDI[i] == -1 {
+				return fallback
+			}
+			i = p.matchingPDI[i]
+		}
+	}
+	return fallback
+}
+
+//resolveExplicitLevels applies the explicit embeddings, overrides and isolates (X1-X8)
+func (p *bidiParagraph) resolveExplicitLevels() {
+	stack := []directionalStatus{{level: p.level, override: bidi.ON}}
+	overflowIsolates, overflowEmbeddings, validIsolates := 0, 0, 0
+
+	for i, t := range p.initialTypes {
+		current := stack[len(stack)-1]
+		switch t {
+		case bidi.RLE, bidi.LRE, bidi.RLO, bidi.LRO:
+			level := nextLevel(current.level, t == bidi.RLE || t == bidi.RLO)
+			if level <= maxBidiDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				override := bidi.ON
+				if t == bidi.RLO {
+					override = bidi.R
+				} else if t == bidi.LRO {
+					override = bidi.L
+				}
+				stack = append(stack, directionalStatus{level: level, override: override})
+			} else if overflowIsolates == 0 {
+				overflowEmbeddings++
+			}
+			p.levels[i] = stack[len(stack)-1].level
+
+		case bidi.RLI, bidi.LRI, bidi.FSI:
+			p.levels[i] = current.level
+			if current.override != bidi.ON {
+				p.types[i] = current.override
+			}
+			rtl := t == bidi.RLI
+			if t == bidi.FSI {
+				end := p.matchingPDI[i]
+				if end == -1 {
+					end = len(p.runes)
+				}
+				rtl = p.firstStrongLevel(i+1, end, 0) == 1
+			}
+			level := nextLevel(current.level, rtl)
+			if level <= maxBidiDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				validIsolates++
+				stack = append(stack, directionalStatus{level: level, override: bidi.ON, isolate: true})
+			} else {
+				overflowIsolates++
+			}
+
+		case bidi.PDI:
+			if overflowIsolates > 0 {
+				overflowIsolates--
+			} else if validIsolates > 0 {
+				overflowEmbeddings = 0
+				for !stack[len(stack)-1].isolate {
+					stack = stack[:len(stack)-1]
+				}
+				stack = stack[:len(stack)-1]
+				validIsolates--
+			}
+			current = stack[len(stack)-1]
+			p.levels[i] = current.level
+			if current.override != bidi.ON {
+				p.types[i] = current.override
+			}
+
+		case bidi.PDF:
+			p.levels[i] = current.level
+			if overflowIsolates > 0 {
+				break
+			}
+			if overflowEmbeddings > 0 {
+				overflowEmbeddings--
+			} else if !current.isolate && len(stack) >= 2 {
+				stack = stack[:len(stack)-1]
+			}
+
+		case bidi.B:
+			p.levels[i] = p.level
+
+		default:
+			p.levels[i] = current.level
+			if current.override != bidi.ON && t != bidi.BN {
+				p.types[i] = current.override
+			}
+		}
+	}
+}
+
+//nextLevel returns the least odd (rtl) or even level greater than level
+func nextLevel(level int, rtl bool) int {
+	if rtl {
+		return (level + 1) | 1
+	}
+	return (level + 2) &^ 1
+}
+
+//isRemovedByX9 checks if the class is ignored by the algorithm after the explicit levels (X9)
+func isRemovedByX9(t bidi.Class) bool {
+	switch t {
+	case bidi.RLE, bidi.LRE, bidi.RLO, bidi.LRO, bidi.PDF, bidi.BN:
+		return true
+	}
+	return false
+}
+
+//isIsolateInitiator checks if the class starts an isolate
+func isIsolateInitiator(t bidi.Class) bool {
+	return t == bidi.LRI || t == bidi.RLI || t == bidi.FSI
+}
+
+//isolatingRunSequences splits the paragraph into isolating run sequences (BD13, X10)
+func (p *bidiParagraph) isolatingRunSequences() [][]int {
+	//Level runs ignoring the removed characters
+	var runs [][]int
+	runOf := make([]int, len(p.runes))
+	for i := range p.runes {
+		if isRemovedByX9(p.initialTypes[i]) {
+			continue
+		}
+		if len(runs) == 0 || p.levels[runs[len(runs)-1][0]] != p.levels[i] {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], i)
+		runOf[i] = len(runs) - 1
+	}
+
+	var sequences [][]int
+	for _, run := range runs {
+		first := run[0]
+		if p.initialTypes[first] == bidi.PDI && p.matchingInitiator[first] != -1 {
+			continue
+		}
+		var sequence []int
+		for {
+			sequence = append(sequence, run...)
+			last := run[len(run)-1]
+			if !isIsolateInitiator(p.initialTypes[last]) || p.matchingPDI[last] == -1 {
+				break
+			}
+			run = runs[runOf[p.matchingPDI[last]]]
+		}
+		sequences = append(sequences, sequence)
+	}
+	return sequences
+}
+
+//resolveSequence resolves the weak and neutral types and the implicit levels of an isolating run sequence
+func (p *bidiParagraph) resolveSequence(sequence []int) {
+	level := p.levels[sequence[0]]
+	types := make([]bidi.Class, len(sequence))
+	for k, i := range sequence {
+		types[k] = p.types[i]
+	}
+
+	//Start and end of sequence types
+	before := p.level
+	for i := sequence[0] - 1; i >= 0; i-- {
+		if !isRemovedByX9(p.initialTypes[i]) {
+			before = p.explicitLevels[i]
+			break
+		}
+	}
+	after := p.level
+	if last := sequence[len(sequence)-1]; !isIsolateInitiator(p.initialTypes[last]) {
+		for i := last + 1; i < len(p.runes); i++ {
+			if !isRemovedByX9(p.initialTypes[i]) {
+				after = p.explicitLevels[i]
+				break
+			}
+		}
+	}
+	sos := directionOfLevel(maxInt(before, level))
+	eos := directionOfLevel(maxInt(after, level))
+	embedding := directionOfLevel(level)
+
+	resolveWeakTypes(types, sos)
+	p.resolveBracketPairs(sequence, types, sos, embedding)
+	resolveNeutralTypes(types, sos, eos, embedding)
+
+	//Implicit levels (I1, I2)
+	for k, i := range sequence {
+		p.types[i] = types[k]
+		switch {
+		case level%2 == 0 && types[k] == bidi.R:
+			p.levels[i] = level + 1
+		case level%2 == 0 && (types[k] == bidi.AN || types[k] == bidi.EN):
+			p.levels[i] = level + 2
+		case level%2 == 1 && (types[k] == bidi.L || types[k] == bidi.AN || types[k] == bidi.EN):
+			p.levels[i] = level + 1
+		default:
+			p.levels[i] = level
+		}
+	}
+}
+
+//resolveWeakTypes applies the rules W1-W7 to the types of an isolating run sequence
+func resolveWeakTypes(types []bidi.Class, sos bidi.Class) {
+	//W1: Non spacing marks take the type of the previous character
+	for k, t := range types {
+		if t != bidi.NSM {
+			continue
+		}
+		switch {
+		case k == 0:
+			types[k] = sos
+		case isIsolateInitiator(types[k-1]) || types[k-1] == bidi.PDI:
+			types[k] = bidi.ON
+		default:
+			types[k] = types[k-1]
+		}
+	}
+
+	//W2, W3: European numbers after arabic letters are arabic numbers, arabic letters are R
+	lastStrong := sos
+	for k, t := range types {
+		switch t {
+		case bidi.L, bidi.R, bidi.AL:
+			lastStrong = t
+		case bidi.EN:
+			if lastStrong == bidi.AL {
+				types[k] = bidi.AN
+			}
+		}
+	}
+	for k, t := range types {
+		if t == bidi.AL {
+			types[k] = bidi.R
+		}
+	}
+
+	//W4: A single separator between two numbers of the same type
+	for k := 1; k < len(types)-1; k++ {
+		prev, next := types[k-1], types[k+1]
+		switch {
+		case types[k] == bidi.ES && prev == bidi.EN && next == bidi.EN:
+			types[k] = bidi.EN
+		case types[k] == bidi.CS && prev == bidi.EN && next == bidi.EN:
+			types[k] = bidi.EN
+		case types[k] == bidi.CS && prev == bidi.AN && next == bidi.AN:
+			types[k] = bidi.AN
+		}
+	}
+
+	//W5: Terminators adjacent to european numbers
+	for k := 0; k < len(types); k++ {
+		if types[k] != bidi.ET {
+			continue
+		}
+		end := k
+		for end < len(types) && types[end] == bidi.ET {
+			end++
+		}
+		if (k > 0 && types[k-1] == bidi.EN) || (end < len(types) && types[end] == bidi.EN) {
+			for ; k < end; k++ {
+				types[k] = bidi.EN
+			}
+		}
+		k = end
+	}
+
+	//W6: Remaining separators and terminators are neutrals
+	for k, t := range types {
+		if t == bidi.ES || t == bidi.ET || t == bidi.CS {
+			types[k] = bidi.ON
+		}
+	}
+
+	//W7: European numbers after L are L
+	lastStrong = sos
+	for k, t := range types {
+		switch t {
+		case bidi.L, bidi.R:
+			lastStrong = t
+		case bidi.EN:
+			if lastStrong == bidi.L {
+				types[k] = bidi.L
+			}
+		}
+	}
+}
+
+//resolveBracketPairs applies the rule N0 to the types of an isolating run sequence
+func (p *bidiParagraph) resolveBracketPairs(sequence []int, types []bidi.Class, sos, embedding bidi.Class) {
+	for _, pair := range p.bracketPairs(sequence, types) {
+		open, close := pair[0], pair[1]
+		//Look for strong types inside the brackets
+		found := bidi.ON
+		for k := open + 1; k < close; k++ {
+			if dir := strongDirection(types[k]); dir == embedding {
+				found = dir
+				break
+			} else if dir != bidi.ON {
+				found = dir
+			}
+		}
+		if found == bidi.ON {
+			continue
+		}
+		//Opposite direction inside the brackets, check the context before them
+		if found != embedding {
+			context := sos
+			for k := open - 1; k >= 0; k-- {
+				if dir := strongDirection(types[k]); dir != bidi.ON {
+					context = dir
+					break
+				}
+			}
+			if context != found {
+				found = embedding
+			}
+		}
+		types[open], types[close] = found, found
+		//Non spacing marks following the brackets take their type
+		for _, k := range pair {
+			for k++; k < len(types) && p.initialTypes[sequence[k]] == bidi.NSM; k++ {
+				types[k] = found
+			}
+		}
+	}
+}
+
+//bracketPairs locates the pairs of brackets in an isolating run sequence (BD16)
+func (p *bidiParagraph) bracketPairs(sequence []int, types []bidi.Class) [][2]int {
+	type opener struct {
+		closing  rune
+		position int
+	}
+	var openers []opener
+	var pairs [][2]int
+	for k, i := range sequence {
+		if types[k] != bidi.ON {
+			continue
+		}
+		r := canonicalBracket(p.runes[i])
+		if closing, ok := bidiBrackets[r]; ok {
+			if len(openers) == maxBracketPairs {
+				break
+			}
+			openers = append(openers, opener{closing, k})
+		} else if _, ok := bidiClosingBrackets[r]; ok {
+			for s := len(openers) - 1; s >= 0; s-- {
+				if openers[s].closing == r {
+					pairs = append(pairs, [2]int{openers[s].position, k})
+					openers = openers[:s]
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(pairs, func(a, b int) bool { return pairs[a][0] < pairs[b][0] })
+	return pairs
+}
+
+//resolveNeutralTypes applies the rules N1, N2 to the types of an isolating run sequence
+func resolveNeutralTypes(types []bidi.Class, sos, eos, embedding bidi.Class) {
+	for k := 0; k < len(types); {
+		if !isNeutral(types[k]) {
+			k++
+			continue
+		}
+		start := k
+		for k < len(types) && isNeutral(types[k]) {
+			k++
+		}
+		leading, trailing := sos, eos
+		if start > 0 {
+			leading = strongDirection(types[start-1])
+		}
+		if k < len(types) {
+			trailing = strongDirection(types[k])
+		}
+		resolved := embedding
+		if leading == trailing {
+			resolved = leading
+		}
+		for i := start; i < k; i++ {
+			types[i] = resolved
+		}
+	}
+}
+
+//isNeutral checks if the class is a neutral or isolate formatting character (NI)
+func isNeutral(t bidi.Class) bool {
+	switch t {
+	case bidi.B, bidi.S, bidi.WS, bidi.ON, bidi.LRI, bidi.RLI, bidi.FSI, bidi.PDI:
+		return true
+	}
+	return false
+}
+
+//strongDirection returns the strong direction of a resolved type, numbers are treated as R
+func strongDirection(t bidi.Class) bidi.Class {
+	switch t {
+	case bidi.L:
+		return bidi.L
+	case bidi.R, bidi.AL, bidi.EN, bidi.AN:
+		return bidi.R
+	}
+	return bidi.ON
+}
+
+//directionOfLevel returns the direction of an embedding level
+func directionOfLevel(level int) bidi.Class {
+	if level%2 == 1 {
+		return bidi.R
+	}
+	return bidi.L
+}
+
+//maxInt returns the larger of two ints
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+//visualOrder returns the indices of the runes between start and end in visual order (L1, L2)
+func (p *bidiParagraph) visualOrder(start, end int) []int {
+	levels := p.lineLevels(start, end)
+	order := make([]int, end-start)
+	for i := range order {
+		order[i] = start + i
+	}
+
+	highest, lowestOdd := 0, maxBidiDepth+2
+	for _, level := range levels {
+		if level > highest {
+			highest = level
+		}
+		if level%2 == 1 && level < lowestOdd {
+			lowestOdd = level
+		}
+	}
+	//Reverse any sequence at a level or higher, from the highest level to the lowest odd level
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(levels); {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(levels) && levels[j] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
+
+//lineLevels returns the levels of a line with the trailing whitespace reset to the paragraph level (L1)
+func (p *bidiParagraph) lineLevels(start, end int) []int {
+	levels := make([]int, end-start)
+	copy(levels, p.levels[start:end])
+	trailing := true
+	for i := end - 1; i >= start; i-- {
+		switch t := p.initialTypes[i]; {
+		case t == bidi.S || t == bidi.B:
+			levels[i-start] = p.level
+			trailing = true
+		case trailing && (t == bidi.WS || isIsolateInitiator(t) || t == bidi.PDI || isRemovedByX9(t)):
+			levels[i-start] = p.level
+		default:
+			trailing = false
+		}
+	}
+	return levels
+}
+
+//visualRune returns the rune at index i as displayed, mirrored if it's in a right to left run (L4)
+func (p *bidiParagraph) visualRune(i int) rune {
+	if p.levels[i]%2 == 1 {
+		return mirrorRune(p.runes[i])
+	}
+	return p.runes[i]
+}
+
+//reorder converts a logical text into its visual order, each line is a separate paragraph
+func reorder(input string, dir Direction) string {
+	var output strings.Builder
+	output.Grow(len(input))
+	runes := []rune(input)
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && bidiClass(runes[end]) != bidi.B {
+			end++
+		}
+		paragraph := newBidiParagraph(runes[start:end], dir)
+		for _, i := range paragraph.visualOrder(0, end-start) {
+			output.WriteRune(paragraph.visualRune(i))
+		}
+		//Paragraph separator stays at the end of the line
+		if end < len(runes) {
+			output.WriteRune(runes[end])
+			end++
+		}
+		start = end
+	}
+	return output.String()
+}
+
+//mirrorRune returns the mirrored glyph of a rune displayed right to left
+func mirrorRune(r rune) rune {
+	if mirror, ok := bidiMirroring[r]; ok {
+		return mirror
+	}
+	return r
+}
+
+//canonicalBracket maps the angle brackets to their canonical equivalents
+func canonicalBracket(r rune) rune {
+	switch r {
+	case '\u2329':
+		return '\u3008'
+	case '\u232A':
+		return '\u3009'
+	}
+	return r
+}
+
+//bidiBrackets maps opening brackets to their closing brackets (BidiBrackets.txt)
+var bidiBrackets = map[rune]rune{
+	'(': ')', '[': ']', '{': '}',
+	'༺': '༻', '༼': '༽', '᚛': '᚜',
+	'⁅': '⁆', '⁽': '⁾', '₍': '₎',
+	'⌈': '⌉', '⌊': '⌋', '\u2329': '\u232A',
+	'❨': '❩', '❪': '❫', '❬': '❭',
+	'❮': '❯', '❰': '❱', '❲': '❳',
+	'❴': '❵', '⟅': '⟆', '⟦': '⟧',
+	'⟨': '⟩', '⟪': '⟫', '⟬': '⟭',
+	'⟮': '⟯', '⦃': '⦄', '⦅': '⦆',
+	'⦇': '⦈', '⦉': '⦊', '⦋': '⦌',
+	'⦍': '⦐', '⦏': '⦎', '⦑': '⦒',
+	'⦓': '⦔', '⦕': '⦖', '⦗': '⦘',
+	'⧘': '⧙', '⧚': '⧛', '⧼': '⧽',
+	'⸢': '⸣', '⸤': '⸥', '⸦': '⸧',
+	'⸨': '⸩', '〈': '〉', '《': '》',
+	'「': '」', '『': '』', '【': '】',
+	'〔': '〕', '〖': '〗', '〘': '〙',
+	'〚': '〛', '﹙': '﹚', '﹛': '﹜',
+	'﹝': '﹞', '（': '）', '［': '］',
+	'｛': '｝', '｟': '｠', '｢': '｣',
+}
+
+//bidiClosingBrackets maps closing brackets to their opening brackets
+var bidiClosingBrackets = func() map[rune]rune {
+	closing := make(map[rune]rune, len(bidiBrackets))
+	for open, close := range bidiBrackets {
+		closing[close] = open
+	}
+	return closing
+}()
+
+//bidiMirroredPairs lists the pairs of Bidi_Mirroring_Glyph characters (BidiMirroring.txt)
+//that are not brackets, brackets are mirrored using bidiBrackets
+var bidiMirroredPairs = [][2]rune{
+	{'<', '>'}, {'«', '»'}, {'‹', '›'},
+	{'∈', '∋'}, {'∉', '∌'}, {'∊', '∍'},
+	{'∕', '⧵'}, {'∼', '∽'}, {'≃', '⋍'},
+	{'≒', '≓'}, {'≔', '≕'}, {'≤', '≥'},
+	{'≦', '≧'}, {'≨', '≩'}, {'≪', '≫'},
+	{'≮', '≯'}, {'≰', '≱'}, {'≲', '≳'},
+	{'≴', '≵'}, {'≶', '≷'}, {'≸', '≹'},
+	{'≺', '≻'}, {'≼', '≽'}, {'≾', '≿'},
+	{'⊀', '⊁'}, {'⊂', '⊃'}, {'⊄', '⊅'},
+	{'⊆', '⊇'}, {'⊈', '⊉'}, {'⊊', '⊋'},
+	{'⊏', '⊐'}, {'⊑', '⊒'}, {'⊘', '⦸'},
+	{'⊢', '⊣'}, {'⊦', '⫞'}, {'⊨', '⫤'},
+	{'⊩', '⫣'}, {'⊫', '⫥'}, {'⊰', '⊱'},
+	{'⊲', '⊳'}, {'⊴', '⊵'}, {'⊶', '⊷'},
+	{'⋉', '⋊'}, {'⋋', '⋌'}, {'⋐', '⋑'},
+	{'⋖', '⋗'}, {'⋘', '⋙'}, {'⋚', '⋛'},
+	{'⋜', '⋝'}, {'⋞', '⋟'}, {'⋠', '⋡'},
+	{'⋢', '⋣'}, {'⋤', '⋥'}, {'⋦', '⋧'},
+	{'⋨', '⋩'}, {'⋪', '⋫'}, {'⋬', '⋭'},
+	{'⋰', '⋱'}, {'⋲', '⋺'}, {'⋳', '⋻'},
+	{'⋴', '⋼'}, {'⋶', '⋽'}, {'⋷', '⋾'},
+	{'⟃', '⟄'}, {'⟈', '⟉'}, {'⟕', '⟖'},
+	{'⟝', '⟞'}, {'⟢', '⟣'}, {'⟤', '⟥'},
+	{'⧀', '⧁'}, {'⧄', '⧅'}, {'⧏', '⧐'},
+	{'⧑', '⧒'}, {'⧔', '⧕'},
+	{'⧸', '⧹'}, {'⨫', '⨬'}, {'⨭', '⨮'},
+	{'⨴', '⨵'}, {'⨼', '⨽'}, {'⩤', '⩥'},
+	{'⩹', '⩺'}, {'⩽', '⩾'}, {'⩿', '⪀'},
+	{'⪁', '⪂'}, {'⪃', '⪄'}, {'⪋', '⪌'},
+	{'⪑', '⪒'}, {'⪓', '⪔'}, {'⪕', '⪖'},
+	{'⪗', '⪘'}, {'⪙', '⪚'}, {'⪛', '⪜'},
+	{'⪡', '⪢'}, {'⪦', '⪧'}, {'⪨', '⪩'},
+	{'⪪', '⪫'}, {'⪬', '⪭'}, {'⪯', '⪰'},
+	{'⪳', '⪴'}, {'⪻', '⪼'}, {'⪽', '⪾'},
+	{'⪿', '⫀'}, {'⫁', '⫂'}, {'⫃', '⫄'},
+	{'⫅', '⫆'}, {'⫍', '⫎'}, {'⫏', '⫐'},
+	{'⫑', '⫒'}, {'⫓', '⫔'}, {'⫕', '⫖'},
+	{'⫬', '⫭'}, {'⫷', '⫸'}, {'⫹', '⫺'},
+	{'⸂', '⸃'}, {'⸄', '⸅'}, {'⸉', '⸊'},
+	{'⸌', '⸍'}, {'⸜', '⸝'}, {'⸠', '⸡'},
+	{'﹤', '﹥'}, {'＜', '＞'},
+}
+
+//bidiMirroring maps the mirrored characters to their mirrored glyphs
+var bidiMirroring = func() map[rune]rune {
+	mirroring := make(map[rune]rune, 2*(len(bidiBrackets)+len(bidiMirroredPairs)))
+	for open, close := range bidiBrackets {
+		mirroring[open], mirroring[close] = close, open
+	}
+	for _, pair := range bidiMirroredPairs {
+		mirroring[pair[0]], mirroring[pair[1]] = pair[1], pair[0]
+	}
+	return mirroring
+}()
@@ -97,6 +97,108 @@ var normalizeTestCases = []struct {
 	},
 }
 
+//normalizerTestCases contains all test cases for TestNormalizer function
+var normalizerTestCases = []struct {
+	description string
+	options     NormalizeOptions
+	input       string
+	expected    string
+}{
+	{
+		description: "Keeping TehMarbuta while folding hamzas",
+		options:     NormalizeOptions{FoldAlef: true, StripShortVowels: true},
+		input:       "أَمِيرة",
+		expected:    "اميرة",
+	},
+	{
+		description: "Folding hamza on waw and yae",
+		options:     NormalizeOptions{FoldHamzaOnWaw: true, FoldHamzaOnYae: true},
+		input:       "مؤمن بئر",
+		expected:    "مومن بير",
+	},
+	{
+		description: "Stripping tatweel only",
+		options:     NormalizeOptions{StripTatweel: true},
+		input:       "بَريـــد",
+		expected:    "بَريد",
+	},
+	{
+		description: "Stripping tanwin and keeping other harakat",
+		options:     NormalizeOptions{StripTanwin: true},
+		input:       "كِتابٌ",
+		expected:    "كِتاب",
+	},
+	{
+		description: "Stripping shadda and sukun",
+		options:     NormalizeOptions{StripShadda: true, StripSukun: true},
+		input:       "مُدَّرْس",
+		expected:    "مُدَرس",
+	},
+	{
+		description: "Stripping dagger alef and folding waslah",
+		options:     NormalizeOptions{StripDaggerAlef: true, FoldAlefWaslah: true},
+		input:       "ٱلرَّحْمَٰن",
+		expected:    "الرَّحْمَن",
+	},
+	{
+		description: "Folding yae without touching alef",
+		options:     NormalizeOptions{FoldYae: true},
+		input:       "إلى",
+		expected:    "إلي",
+	},
+	{
+		description: "No options leave the text unchanged",
+		options:     NormalizeOptions{},
+		input:       "قُرْآنٌ",
+		expected:    "قُرْآنٌ",
+	},
+}
+
+//alignmentTestCases contains all test cases for projecting normalized matches onto the original text
+var alignmentTestCases = []struct {
+	description string
+	input       string
+	query       string
+	expected    string
+}{
+	{
+		description: "Projecting a word with harakat",
+		input:       "مُحَمَّدٌ رَسُولُ اللَّهِ",
+		query:       "رسول",
+		expected:    "رَسُولُ",
+	},
+	{
+		description: "Projecting the first word",
+		input:       "مُحَمَّدٌ رَسُولُ اللَّهِ",
+		query:       "محمد",
+		expected:    "مُحَمَّدٌ",
+	},
+	{
+		description: "Projecting a folded word",
+		input:       "ذهبتُ إلى المكتبةِ",
+		query:       "الي",
+		expected:    "إلى",
+	},
+	{
+		description: "Projecting a word with tatweel",
+		input:       "البريـــد الالكتروني",
+		query:       "البريد",
+		expected:    "البريـــد",
+	},
+	{
+		description: "Projecting part of a word",
+		input:       "الجامِعَةَ",
+		query:       "جامعه",
+		expected:    "جامِعَةَ",
+	},
+	{
+		description: "Projecting a word after removed runes at the start",
+		input:       "ـَمحمد",
+		query:       "محمد",
+		expected:    "ـَمحمد",
+	},
+}
+
 //spellNumberTestCases contains all test cases for reading a number in arabic
 var spellNumberTestCases = []struct {
 	input    int
@@ -178,36 +280,552 @@ var spellNumberTestCases = []struct {
 		1000,
 		"ألف",
 	},
+	{
+		21,
+		"واحد و عشرون",
+	},
+	{
+		101,
+		"مئة و واحد",
+	},
+	{
+		110,
+		"مئة و عشرة",
+	},
+	{
+		120,
+		"مئة و عشرون",
+	},
+	{
+		200,
+		"مئتان",
+	},
+	{
+		999,
+		"تسعمئة و تسعة و تسعون",
+	},
+	{
+		1001,
+		"ألف و واحد",
+	},
 	{
 		1250,
 		"ألف و مئتان و خمسون",
 	},
+	{
+		2000,
+		"ألفان",
+	},
+	{
+		2021,
+		"ألفان و واحد و عشرون",
+	},
+	{
+		3000,
+		"ثلاثة آلاف",
+	},
+	{
+		10000,
+		"عشرة آلاف",
+	},
+	{
+		11000,
+		"أحد عشر ألفًا",
+	},
 	{
 		11225,
-		"أحد عشر ألف و مئتان و خمسة و عشرون",
+		"أحد عشر ألفًا و مئتان و خمسة و عشرون",
+	},
+	{
+		25000,
+		"خمسة و عشرون ألفًا",
+	},
+	{
+		99000,
+		"تسعة و تسعون ألفًا",
 	},
 	{
 		100000,
 		"مئة ألف",
 	},
+	{
+		101000,
+		"مئة ألف و ألف",
+	},
+	{
+		102000,
+		"مئة ألف و ألفان",
+	},
+	{
+		103000,
+		"مئة و ثلاثة آلاف",
+	},
+	{
+		115000,
+		"مئة و خمسة عشر ألفًا",
+	},
+	{
+		200000,
+		"مئتا ألف",
+	},
+	{
+		300000,
+		"ثلاثمئة ألف",
+	},
 	{
 		1000000,
 		"مليون",
 	},
 	{
 		-2000000,
-		"سالب اثنان مليون",
+		"سالب مليونان",
+	},
+	{
+		7000000,
+		"سبعة ملايين",
+	},
+	{
+		1000001000,
+		"مليار و ألف",
+	},
+	{
+		2000000000,
+		"ملياران",
+	},
+	{
+		5000000000,
+		"خمسة مليارات",
 	},
 	{
 		141592653589,
-		"مئة و واحد و أربعون مليار و خمسمئة و اثنان و تسعون مليون و ستمئة و ثلاثة و خمسون ألف و خمسمئة و تسعة و ثمانون",
+		"مئة و واحد و أربعون مليارًا و خمسمئة و اثنان و تسعون مليونًا و ستمئة و ثلاثة و خمسون ألفًا و خمسمئة و تسعة و ثمانون",
 	},
 	{
 		141592653589,
-		"مئة و واحد و أربعون مليار و خمسمئة و اثنان و تسعون مليون و ستمئة و ثلاثة و خمسون ألف و خمسمئة و تسعة و ثمانون",
+		"مئة و واحد و أربعون مليارًا و خمسمئة و اثنان و تسعون مليونًا و ستمئة و ثلاثة و خمسون ألفًا و خمسمئة و تسعة و ثمانون",
+	},
+}
+
+//spellFeminineNumberTestCases contains all test cases for reading a number counting a feminine noun
+var spellFeminineNumberTestCases = []struct {
+	input    int
+	expected string
+}{
+	{0, "صفر"},
+	{1, "واحدة"},
+	{2, "اثنتان"},
+	{3, "ثلاث"},
+	{8, "ثماني"},
+	{10, "عشر"},
+	{11, "إحدى عشرة"},
+	{12, "اثنتا عشرة"},
+	{13, "ثلاث عشرة"},
+	{18, "ثماني عشرة"},
+	{20, "عشرون"},
+	{21, "إحدى و عشرون"},
+	{22, "اثنتان و عشرون"},
+	{35, "خمس و ثلاثون"},
+	{100, "مئة"},
+	{103, "مئة و ثلاث"},
+	{219, "مئتان و تسع عشرة"},
+	{3000, "ثلاثة آلاف"},
+	{3003, "ثلاثة آلاف و ثلاث"},
+	{13013, "ثلاثة عشر ألفًا و ثلاث عشرة"},
+	{-7, "سالب سبع"},
+}
+
+//spellNumberCaseTestCases contains all test cases for reading a number in a grammatical case
+var spellNumberCaseTestCases = []struct {
+	description string
+	input       int
+	opts        SpellOptions
+	expected    string
+}{
+	{"Accusative two", 2, SpellOptions{Case: Accusative}, "اثنين"},
+	{"Genitive feminine two", 2, SpellOptions{Case: Genitive, Feminine: true}, "اثنتين"},
+	{"Accusative twelve", 12, SpellOptions{Case: Accusative}, "اثني عشر"},
+	{"Genitive feminine twelve", 12, SpellOptions{Case: Genitive, Feminine: true}, "اثنتي عشرة"},
+	{"Accusative tens", 20, SpellOptions{Case: Accusative}, "عشرين"},
+	{"Genitive tens", 30, SpellOptions{Case: Genitive}, "ثلاثين"},
+	{"Accusative compound tens", 22, SpellOptions{Case: Accusative}, "اثنين و عشرين"},
+	{"Genitive feminine compound tens", 41, SpellOptions{Case: Genitive, Feminine: true}, "إحدى و أربعين"},
+	{"Accusative hundreds", 250, SpellOptions{Case: Accusative}, "مئتين و خمسين"},
+	{"Accusative dual scale", 2000, SpellOptions{Case: Accusative}, "ألفين"},
+	{"Genitive hundreds in construct", 200000, SpellOptions{Case: Genitive}, "مئتي ألف"},
+	{"Accusative counted scale", 20000, SpellOptions{Case: Accusative}, "عشرين ألفًا"},
+	{"Accusative plural scale", 3000000, SpellOptions{Case: Accusative}, "ثلاثة ملايين"},
+	{"Accusative singular scale", 1000020, SpellOptions{Case: Accusative}, "مليون و عشرين"},
+	{"Genitive large number", 2092070, SpellOptions{Case: Genitive}, "مليونين و اثنين و تسعين ألفًا و سبعين"},
+	{"Nominative is the default", 2092070, SpellOptions{}, "مليونان و اثنان و تسعون ألفًا و سبعون"},
+}
+
+//spellOrdinalTestCases contains all test cases for reading an ordinal in arabic
+var spellOrdinalTestCases = []struct {
+	description string
+	input       int
+	opts        OrdinalOptions
+	expected    string
+}{
+	{"First", 1, OrdinalOptions{Definite: true}, "الأول"},
+	{"Feminine first", 1, OrdinalOptions{Definite: true, Feminine: true}, "الأولى"},
+	{"Second", 2, OrdinalOptions{Definite: true}, "الثاني"},
+	{"Feminine second", 2, OrdinalOptions{Definite: true, Feminine: true}, "الثانية"},
+	{"Tenth", 10, OrdinalOptions{Definite: true}, "العاشر"},
+	{"Eleventh", 11, OrdinalOptions{Definite: true}, "الحادي عشر"},
+	{"Feminine eleventh", 11, OrdinalOptions{Definite: true, Feminine: true}, "الحادية عشرة"},
+	{"Twelfth", 12, OrdinalOptions{Definite: true}, "الثاني عشر"},
+	{"Feminine nineteenth", 19, OrdinalOptions{Definite: true, Feminine: true}, "التاسعة عشرة"},
+	{"Twentieth", 20, OrdinalOptions{Definite: true}, "العشرون"},
+	{"Twenty first", 21, OrdinalOptions{Definite: true}, "الحادي و العشرون"},
+	{"Twenty fifth", 25, OrdinalOptions{Definite: true}, "الخامس و العشرون"},
+	{"Genitive twenty fifth", 25, OrdinalOptions{Definite: true, Case: Genitive}, "الخامس و العشرين"},
+	{"Feminine thirty second", 32, OrdinalOptions{Definite: true, Feminine: true}, "الثانية و الثلاثون"},
+	{"Hundredth", 100, OrdinalOptions{Definite: true}, "المئة"},
+	{"Hundred and first", 101, OrdinalOptions{Definite: true}, "الأول بعد المئة"},
+	{"Feminine hundred and first", 101, OrdinalOptions{Definite: true, Feminine: true}, "الأولى بعد المئة"},
+	{"Hundred and eleventh", 111, OrdinalOptions{Definite: true}, "الحادي عشر بعد المئة"},
+	{"Thousand and twenty first", 1021, OrdinalOptions{Definite: true}, "الحادي و العشرون بعد الألف"},
+	{"Two hundred and twenty fifth", 225, OrdinalOptions{Definite: true}, "الخامس و العشرون بعد المئتين"},
+	{"Thousandth", 1000, OrdinalOptions{Definite: true}, "الألف"},
+	{"Indefinite third", 3, OrdinalOptions{}, "ثالث"},
+	{"Indefinite accusative third", 3, OrdinalOptions{Case: Accusative}, "ثالثًا"},
+	{"Indefinite second", 2, OrdinalOptions{}, "ثانٍ"},
+	{"Indefinite accusative second", 2, OrdinalOptions{Case: Accusative}, "ثانيًا"},
+	{"Indefinite feminine accusative first", 1, OrdinalOptions{Case: Accusative, Feminine: true}, "أولى"},
+	{"Indefinite feminine accusative second", 2, OrdinalOptions{Case: Accusative, Feminine: true}, "ثانيةً"},
+	{"Indefinite feminine accusative twenty first", 21, OrdinalOptions{Case: Accusative, Feminine: true}, "حاديةً و عشرين"},
+	{"Indefinite feminine genitive third", 3, OrdinalOptions{Case: Genitive, Feminine: true}, "ثالثة"},
+	{"Indefinite eleventh", 11, OrdinalOptions{Case: Accusative}, "حادي عشر"},
+	{"Indefinite accusative twenty first", 21, OrdinalOptions{Case: Accusative}, "حاديًا و عشرين"},
+}
+
+//Nouns counted in the quantity test cases
+var (
+	bookNoun    = Noun{"كتاب", "كتابان", "كتب", "كتابًا", false}
+	messageNoun = Noun{"رسالة", "رسالتان", "رسائل", "رسالة", true}
+)
+
+//spellQuantityTestCases contains all test cases for reading a number with the noun it counts
+var spellQuantityTestCases = []struct {
+	description string
+	input       int
+	noun        Noun
+	c           Case
+	expected    string
+}{
+	{"No books", 0, bookNoun, Nominative, "صفر كتاب"},
+	{"One book", 1, bookNoun, Nominative, "كتاب واحد"},
+	{"One message", 1, messageNoun, Nominative, "رسالة واحدة"},
+	{"Two books", 2, bookNoun, Nominative, "كتابان"},
+	{"Three books", 3, bookNoun, Nominative, "ثلاثة كتب"},
+	{"Three messages", 3, messageNoun, Nominative, "ثلاث رسائل"},
+	{"Ten messages", 10, messageNoun, Nominative, "عشر رسائل"},
+	{"Eleven books", 11, bookNoun, Nominative, "أحد عشر كتابًا"},
+	{"Thirteen messages", 13, messageNoun, Nominative, "ثلاث عشرة رسالة"},
+	{"Twenty one books", 21, bookNoun, Nominative, "واحد و عشرون كتابًا"},
+	{"Hundred books", 100, bookNoun, Nominative, "مئة كتاب"},
+	{"Hundred and one books", 101, bookNoun, Nominative, "مئة كتاب و كتاب"},
+	{"Hundred and two messages", 102, messageNoun, Nominative, "مئة رسالة و رسالتان"},
+	{"Hundred and three books", 103, bookNoun, Nominative, "مئة و ثلاثة كتب"},
+	{"Two hundred books", 200, bookNoun, Nominative, "مئتا كتاب"},
+	{"Thousand books", 1000, bookNoun, Nominative, "ألف كتاب"},
+	{"Three thousand messages", 3000, messageNoun, Nominative, "ثلاثة آلاف رسالة"},
+	{"Thousands with plural noun", 3005, messageNoun, Nominative, "ثلاثة آلاف و خمس رسائل"},
+	{"Two thousand books", 2000, bookNoun, Nominative, "ألفا كتاب"},
+	{"Twelve thousand books", 12000, bookNoun, Nominative, "اثنا عشر ألف كتاب"},
+	{"Million books", 1000000, bookNoun, Nominative, "مليون كتاب"},
+	{"Accusative one book", 1, bookNoun, Accusative, "كتابًا واحدًا"},
+	{"Accusative two books", 2, bookNoun, Accusative, "كتابين"},
+	{"Genitive twenty books", 20, bookNoun, Genitive, "عشرين كتابًا"},
+	{"Genitive two thousand books", 2000, bookNoun, Genitive, "ألفي كتاب"},
+	{"Negative quantity", -4, bookNoun, Nominative, "سالب أربعة كتب"},
+}
+
+//spellLargeNumberTestCases contains all test cases for reading large numbers in arabic
+var spellLargeNumberTestCases = []struct {
+	description string
+	input       string
+	expected    string
+	err         error
+}{
+	{
+		"Spelling trillions",
+		"5000000000000",
+		"خمسة تريليونات",
+		nil,
+	},
+	{
+		"Spelling the largest uint64",
+		"18446744073709551615",
+		"ثمانية عشر كوينتليونًا و أربعمئة و ستة و أربعون كوادريليونًا و سبعمئة و أربعة و أربعون تريليونًا و ثلاثة و سبعون مليارًا و سبعمئة و تسعة ملايين و خمسمئة و واحد و خمسون ألفًا و ستمئة و خمسة عشر",
+		nil,
+	},
+	{
+		"Spelling the smallest int64",
+		"-9223372036854775808",
+		"سالب تسعة كوينتليونات و مئتان و ثلاثة و عشرون كوادريليونًا و ثلاثمئة و اثنان و سبعون تريليونًا و ستة و ثلاثون مليارًا و ثمانمئة و أربعة و خمسون مليونًا و سبعمئة و خمسة و سبعون ألفًا و ثمانمئة و ثمانية",
+		nil,
+	},
+	{
+		"Spelling the largest scale",
+		"7000000000000000000000000000000000",
+		"سبعة ديسليونات",
+		nil,
+	},
+	{
+		"Spelling beyond the largest scale",
+		"1000000000000000000000000000000000000",
+		"",
+		ErrNumberTooLarge,
 	},
 }
 
+//spellAmountTestCases contains all test cases for spelling amounts of money
+var spellAmountTestCases = []struct {
+	description string
+	input       string
+	currency    Currency
+	expected    string
+	err         error
+}{
+	{"Riyals and halalas", "1250.25", SAR, "فقط ألف و مئتان و خمسون ريالًا سعوديًا و خمس و عشرون هللة لا غير", nil},
+	{"One riyal", "1", SAR, "فقط ريال سعودي واحد لا غير", nil},
+	{"Two riyals", "2", SAR, "فقط ريالان سعوديان لا غير", nil},
+	{"Plural riyals", "3", SAR, "فقط ثلاثة ريالات سعودية لا غير", nil},
+	{"Accusative riyal", "11", SAR, "فقط أحد عشر ريالًا سعوديًا لا غير", nil},
+	{"Hundred riyals", "100", SAR, "فقط مئة ريال سعودي لا غير", nil},
+	{"Riyal after the hundreds", "101", SAR, "فقط مئة ريال سعودي و ريال سعودي لا غير", nil},
+	{"Two thousand in construct", "2000", SAR, "فقط ألفا ريال سعودي لا غير", nil},
+	{"Eleven thousand in construct", "11000", SAR, "فقط أحد عشر ألف ريال سعودي لا غير", nil},
+	{"Two hundred thousand in construct", "200000", SAR, "فقط مئتا ألف ريال سعودي لا غير", nil},
+	{"One halala", "0.01", SAR, "فقط هللة واحدة لا غير", nil},
+	{"Tenths of a riyal", "2.1", SAR, "فقط ريالان سعوديان و عشر هللات لا غير", nil},
+	{"Trailing zeros", "7.500", SAR, "فقط سبعة ريالات سعودية و خمسون هللة لا غير", nil},
+	{"Arabic digits", "٣٠٠", AED, "فقط ثلاثمئة درهم إماراتي لا غير", nil},
+	{"Egyptian pounds and piasters", "15.05", EGP, "فقط خمسة عشر جنيهًا مصريًا و خمسة قروش لا غير", nil},
+	{"Kuwaiti fils", "1.125", KWD, "فقط دينار كويتي واحد و مئة و خمسة و عشرون فلسًا لا غير", nil},
+	{"Jordanian dinars", "20", JOD, "فقط عشرون دينارًا أردنيًا لا غير", nil},
+	{"Dollars and cents", "1000000.99", USD, "فقط مليون دولار أمريكي و تسعة و تسعون سنتًا لا غير", nil},
+	{"Euros", "4", EUR, "فقط أربعة يورو لا غير", nil},
+	{"Zero", "0", SAR, "فقط صفر ريال سعودي لا غير", nil},
+	{"Thousands separators", "1,250.25", SAR, "فقط ألف و مئتان و خمسون ريالًا سعوديًا و خمس و عشرون هللة لا غير", nil},
+	{"Arabic separators", "١٬٢٥٠٫٢٥", SAR, "فقط ألف و مئتان و خمسون ريالًا سعوديًا و خمس و عشرون هللة لا غير", nil},
+	{"Too many decimals", "1.005", SAR, "", ErrInvalidAmount},
+	{"Not a number", "1,5", SAR, "", ErrInvalidAmount},
+	{"Empty amount", "", SAR, "", ErrInvalidAmount},
+}
+
+//spellDecimalTestCases contains all test cases for reading decimal numbers in arabic
+var spellDecimalTestCases = []struct {
+	description string
+	input       string
+	opts        DecimalOptions
+	expected    string
+	err         error
+}{
+	{"Decimal point", "3.14", DecimalOptions{}, "ثلاثة فاصلة أربعة عشر", nil},
+	{"Leading zeros of the decimals", "3.05", DecimalOptions{}, "ثلاثة فاصلة صفر خمسة", nil},
+	{"Trailing zeros of the decimals", "2.50", DecimalOptions{}, "اثنان فاصلة خمسة", nil},
+	{"Negative decimal", "-0.5", DecimalOptions{}, "سالب صفر فاصلة خمسة", nil},
+	{"Integer", "12", DecimalOptions{}, "اثنا عشر", nil},
+	{"Arabic digits with a point", "٣.١٤", DecimalOptions{}, "ثلاثة فاصلة أربعة عشر", nil},
+	{"Arabic decimal separator", "٣٫١٤", DecimalOptions{}, "ثلاثة فاصلة أربعة عشر", nil},
+	{"Two decimal separators", "٣٫١.٤", DecimalOptions{}, "", ErrInvalidNumber},
+	{"Rounding to precision", "1.2345", DecimalOptions{Precision: 2}, "واحد فاصلة ثلاثة و عشرون", nil},
+	{"Rounding up to an integer", "1.999", DecimalOptions{Precision: 2}, "اثنان", nil},
+	{"Decimal parts", "3.14", DecimalOptions{Style: DecimalParts}, "ثلاثة و أربعة عشر من مئة", nil},
+	{"Decimal parts lower than one", "0.25", DecimalOptions{Style: DecimalParts}, "خمسة و عشرون من مئة", nil},
+	{"Thousandth parts", "7.125", DecimalOptions{Style: DecimalParts}, "سبعة و مئة و خمسة و عشرون من ألف", nil},
+	{"Not a number", "3,14", DecimalOptions{}, "", ErrInvalidNumber},
+	{"Empty number", "", DecimalOptions{}, "", ErrInvalidNumber},
+}
+
+//spellFractionTestCases contains all test cases for reading fractions in arabic
+var spellFractionTestCases = []struct {
+	numerator, denominator int
+	expected               string
+	err                    error
+}{
+	{1, 2, "نصف", nil},
+	{1, 3, "ثلث", nil},
+	{2, 3, "ثلثان", nil},
+	{1, 4, "ربع", nil},
+	{3, 4, "ثلاثة أرباع", nil},
+	{5, 8, "خمسة أثمان", nil},
+	{9, 10, "تسعة أعشار", nil},
+	{7, 15, "سبعة من خمسة عشر", nil},
+	{1, 20, "واحد من عشرين", nil},
+	{3, 2, "واحد و نصف", nil},
+	{11, 4, "اثنان و ثلاثة أرباع", nil},
+	{4, 2, "اثنان", nil},
+	{0, 5, "صفر", nil},
+	{1, -4, "سالب ربع", nil},
+	{1, 0, "", ErrZeroDenominator},
+}
+
+//parseNumberTestCases contains all test cases for reading arabic number words
+var parseNumberTestCases = []struct {
+	description string
+	input       string
+	expected    int64
+	err         error
+}{
+	{"Spaced conjunctions", "ألف و مئتان و خمسون", 1250, nil},
+	{"Attached conjunctions", "ألف ومئتان وخمسون", 1250, nil},
+	{"Hamza variants", "الف و مائتان و خمسون", 1250, nil},
+	{"Harakat", "أَلْفٌ وَخَمْسُونَ", 1050, nil},
+	{"Classical hundreds", "ثلاثمائة و ثلاثة", 303, nil},
+	{"Hundreds written apart", "ثلاث مئة", 300, nil},
+	{"Teens", "أحد عشر", 11, nil},
+	{"Feminine teens", "اثنتا عشرة", 12, nil},
+	{"Accusative", "اثني عشر ألفًا و عشرين", 12020, nil},
+	{"Dual scale", "ألفان", 2000, nil},
+	{"Accusative dual scale", "مليونين", 2000000, nil},
+	{"Plural scale", "ثلاثة ملايين", 3000000, nil},
+	{"Digits and words", "3 ملايين", 3000000, nil},
+	{"Arabic digits and words", "٢٥ ألفًا", 25000, nil},
+	{"Zero", "صفر", 0, nil},
+	{"Negative", "سالب خمسة و عشرون", -25, nil},
+	{"Repeated scale after hundreds", "مئة ألف و ألف", 101000, nil},
+	{"Largest int64", SpellInt64(1<<63 - 1), 1<<63 - 1, nil},
+	{"Too large", "عشرة كوينتليونات", 0, ErrNumberTooLarge},
+	{"Largest int64 in digits", "9223372036854775807", 1<<63 - 1, nil},
+	{"Smallest int64 in digits", "سالب 9223372036854775808", -1 << 63, nil},
+	{"Digits too large", "99999999999999999999", 0, ErrNumberTooLarge},
+	{"Arabic digits too large with a scale", "٩٩٩٩٩٩٩٩٩٩٩٩٩٩٩٩ مليون", 0, ErrNumberTooLarge},
+	{"Unexpected word", "خمسة كتب", 0, &ParseError{Offset: 9, Word: "كتب"}},
+	{"Trailing conjunction", "خمسة و", 0, &ParseError{Offset: 9, Word: "و"}},
+	{"Repeated unit", "خمسة خمسة", 0, &ParseError{Offset: 9, Word: "خمسة"}},
+	{"Repeated tens", "عشرة عشرة", 0, &ParseError{Offset: 9, Word: "عشرة"}},
+	{"Repeated hundreds", "مئة مئة", 0, &ParseError{Offset: 7, Word: "مئة"}},
+	{"Unit before tens without conjunction", "ثلاثة عشرون", 0, &ParseError{Offset: 11, Word: "عشرون"}},
+	{"Repeated scale", "مليون مليون", 0, &ParseError{Offset: 11, Word: "مليون"}},
+	{"Increasing scale", "ألف مليون", 0, &ParseError{Offset: 7, Word: "مليون"}},
+	{"Conjunction before hundreds", "خمسة و مئة", 0, &ParseError{Offset: 12, Word: "مئة"}},
+	{"Not a number", "مرحبا", 0, &ParseError{Offset: 0, Word: "مرحبا"}},
+	{"Empty", "", 0, &ParseError{Offset: 0}},
+}
+
+//extractNumbersTestCases contains all test cases for finding numbers in arabic text,
+//the values of the expected numbers are written as fractions
+var extractNumbersTestCases = []struct {
+	description string
+	input       string
+	expected    []NumberSpan
+	values      []string
+}{
+	{
+		description: "Western digits",
+		input:       "عام 2021، سعر 1,234.5",
+		expected:    []NumberSpan{{NumberDigits, 7, 11, "2021", nil}, {NumberDigits, 21, 28, "1,234.5", nil}},
+		values:      []string{"2021", "2469/2"},
+	},
+	{
+		description: "Arabic separators",
+		input:       "السعر ١٬٢٣٤٫٥ ريال",
+		expected:    []NumberSpan{{NumberDigits, 11, 25, "١٬٢٣٤٫٥", nil}},
+		values:      []string{"2469/2"},
+	},
+	{
+		description: "Extended digits and signs",
+		input:       "دما ۱۲۳ و -٥",
+		expected:    []NumberSpan{{NumberDigits, 7, 13, "۱۲۳", nil}, {NumberDigits, 17, 20, "-٥", nil}},
+		values:      []string{"123", "-5"},
+	},
+	{
+		description: "Digits and scale words",
+		input:       "باع 3 ملايين و ٢٫٥ مليار",
+		expected:    []NumberSpan{{NumberMixed, 7, 21, "3 ملايين", nil}, {NumberMixed, 25, 42, "٢٫٥ مليار", nil}},
+		values:      []string{"3000000", "2500000000"},
+	},
+	{
+		description: "Digits and scale words followed by words",
+		input:       "دفع 3 آلاف و خمسمئة ريال",
+		expected:    []NumberSpan{{NumberMixed, 7, 33, "3 آلاف و خمسمئة", nil}},
+		values:      []string{"3500"},
+	},
+	{
+		description: "Negative digits and scale words followed by words",
+		input:       "-2 مليون و مئة ألف",
+		expected:    []NumberSpan{{NumberMixed, 0, 30, "-2 مليون و مئة ألف", nil}},
+		values:      []string{"-2100000"},
+	},
+	{
+		description: "Spelled numbers",
+		input:       "دفع ألفًا ومئتين وخمسين ريالًا وسافر",
+		expected:    []NumberSpan{{NumberWords, 7, 43, "ألفًا ومئتين وخمسين", nil}},
+		values:      []string{"2250"},
+	},
+	{
+		description: "List of spelled numbers",
+		input:       "الأرقام خمسة ستة سبعة",
+		expected:    []NumberSpan{{NumberWords, 15, 23, "خمسة", nil}, {NumberWords, 24, 30, "ستة", nil}, {NumberWords, 31, 39, "سبعة", nil}},
+		values:      []string{"5", "6", "7"},
+	},
+	{
+		description: "Increasing scales",
+		input:       "ألف مليون دولار",
+		expected:    []NumberSpan{{NumberWords, 0, 6, "ألف", nil}, {NumberWords, 7, 17, "مليون", nil}},
+		values:      []string{"1000", "1000000"},
+	},
+	{
+		description: "Punctuation ends numbers",
+		input:       "خمسة، و ستة",
+		expected:    []NumberSpan{{NumberWords, 0, 8, "خمسة", nil}, {NumberWords, 14, 20, "ستة", nil}},
+		values:      []string{"5", "6"},
+	},
+	{
+		description: "Separators outside groups",
+		input:       "1,5 و 2020-2021",
+		expected:    []NumberSpan{{NumberDigits, 0, 1, "1", nil}, {NumberDigits, 2, 3, "5", nil}, {NumberDigits, 7, 11, "2020", nil}, {NumberDigits, 12, 16, "2021", nil}},
+		values:      []string{"1", "5", "2020", "2021"},
+	},
+	{
+		description: "No numbers",
+		input:       "مرحبا بالعالم",
+	},
+}
+
+//formatNumberTestCases contains all test cases for writing numbers in the arabic locales,
+//a negative precision writes the shortest decimals
+var formatNumberTestCases = []struct {
+	description string
+	input       float64
+	precision   int
+	locale      Locale
+	expected    string
+}{
+	{"Gulf integer", 1234, 0, LocaleGulf, "١٬٢٣٤"},
+	{"Gulf decimal", 1234.5, -1, LocaleGulf, "١٬٢٣٤٫٥"},
+	{"Egypt millions", -1234567.25, 2, LocaleEgypt, "-١٬٢٣٤٬٥٦٧٫٢٥"},
+	{"Maghreb decimal", 1234.5, 2, LocaleMaghreb, "1.234,50"},
+	{"Small numbers aren't grouped", 123, -1, LocaleGulf, "١٢٣"},
+	{"Rounding", 0.125, 2, LocaleGulf, "٠٫١٢"},
+}
+
+//parseFormattedTestCases contains all test cases for reading numbers written in the arabic locales
+var parseFormattedTestCases = []struct {
+	description string
+	input       string
+	locale      Locale
+	expected    float64
+	err         error
+}{
+	{"Arabic separators", "١٬٢٣٤٫٥", LocaleGulf, 1234.5, nil},
+	{"Western separators in the gulf", "1,234.5", LocaleGulf, 1234.5, nil},
+	{"Maghreb separators", "1.234,5", LocaleMaghreb, 1234.5, nil},
+	{"Arabic separators in the maghreb", "١٬٢٣٤٫٥", LocaleMaghreb, 1234.5, nil},
+	{"Extended digits", "۱۲۳", LocaleGulf, 123, nil},
+	{"Percent", "١٢٫٥٪", LocaleGulf, 12.5, nil},
+	{"Western percent", "25%", LocaleMaghreb, 25, nil},
+	{"Negative with a bidi mark", "\u061c-١٢", LocaleEgypt, -12, nil},
+	{"Spaces", " ٤٢ ", LocaleGulf, 42, nil},
+	{"Misplaced thousands separator", "١٬٢٣", LocaleGulf, 0, ErrInvalidNumber},
+	{"Western decimal in the maghreb", "1.5", LocaleMaghreb, 0, ErrInvalidNumber},
+	{"Not a number", "abc", LocaleGulf, 0, ErrInvalidNumber},
+	{"Empty", "", LocaleGulf, 0, ErrInvalidNumber},
+}
+
 //tashkeelTestCases contains all test cases for adding tashkeel to arabic text
 var tashkeelTestCases = []struct {
 	description string
@@ -250,16 +868,733 @@ var shapingTestCases = []struct {
 	{
 		"Shaping  1 sentence with tashkeel",
 		"قِفا نَبكِ مِن ذِكرى حَبيبٍ وَمَنزِلِ   ****   بِسِقطِ اللِوى بَينَ الدَخولِ فَحَومَلِ",
-		"ِﻞَﻣﻮَﺤَﻓ ِﻝﻮﺧَﺪﻟا َﻦﻴَﺑ ﻯﻮِﻠﻟا ِﻂﻘِﺴِﺑ **** ِﻝِﺰﻨَﻣَو ٍﺐﻴﺒَﺣ ﻯﺮﻛِذ ﻦِﻣ ِﻚﺒَﻧ ﺎﻔِﻗ",
+		"ِﻞَﻣﻮَﺤَﻓ ِﻝﻮﺧَﺪﻟﺍ َﻦﻴَﺑ ﻯﻮِﻠﻟﺍ ِﻂﻘِﺴِﺑ   ****   ِﻝِﺰﻨَﻣَﻭ ٍﺐﻴﺒَﺣ ﻯﺮﻛِﺫ ﻦِﻣ ِﻚﺒَﻧ ﺎﻔِﻗ",
 	},
 
 	{
 		"Shaping 1 word without tashkeel",
 		"المصفوفة (Multidimentional Array) هي",
-		"ﻲﻫ (Multidimentional Array) ﺔﻓﻮﻔﺼﻤﻟا",
+		"ﻲﻫ (Multidimentional Array) ﺔﻓﻮﻔﺼﻤﻟﺍ",
+	},
+	{
+		"Shaping sentence with numbers",
+		"السعر 12.5 ريال",
+		"ﻝﺎﻳﺭ 12.5 ﺮﻌﺴﻟﺍ",
+	},
+	{
+		"Shaping sentence with arabic digits",
+		"القيمة ٣٤٥ ريالا",
+		"ﻻﺎﻳﺭ ٣٤٥ ﺔﻤﻴﻘﻟﺍ",
+	},
+	{
+		"Shaping sentence with percentage",
+		"أسعار 10% فقط",
+		"ﻂﻘﻓ %10 ﺭﺎﻌﺳﺃ",
+	},
+	{
+		"Shaping nested parentheses",
+		"(مرحبا (عالم))",
+		"((ﻢﻟﺎﻋ) ﺎﺒﺣﺮﻣ)",
+	},
+	{
+		"Shaping sentence with several english words",
+		"كتب Go و Rust في 2020م",
+		"ﻡ2020 ﻲﻓ Rust ﻭ Go ﺐﺘﻛ",
+	},
+	{
+		"Shaping multiple lines",
+		"نص عربي\nسطر ثاني",
+		"ﻲﺑﺮﻋ ﺺﻧ\nﻲﻧﺎﺛ ﺮﻄﺳ",
+	},
+	{
+		"Mirroring parentheses",
+		"(مثال)",
+		"(ﻝﺎﺜﻣ)",
+	},
+	{
+		"Mirroring square brackets and braces",
+		"[مثال] و {آخر}",
+		"{ﺮﺧﺁ} ﻭ [ﻝﺎﺜﻣ]",
+	},
+	{
+		"Mirroring quotation marks",
+		"قال «نعم» ثم",
+		"ﻢﺛ «ﻢﻌﻧ» ﻝﺎﻗ",
+	},
+	{
+		"Mirroring single quotation marks",
+		"‹نص›",
+		"‹ﺺﻧ›",
+	},
+	{
+		"Mirroring less than sign",
+		"س < ص",
+		"ﺹ > ﺱ",
+	},
+	{
+		"Mirroring mathematical relations",
+		"س ≤ ص",
+		"ﺹ ≥ ﺱ",
+	},
+	{
+		"Shaping persian word with peh",
+		"پدر",
+		"ﺭﺪﭘ",
+	},
+	{
+		"Shaping persian word with gaf",
+		"گرگ",
+		"ﮒﺮﮔ",
+	},
+	{
+		"Shaping persian word with keheh",
+		"کتاب",
+		"ﺏﺎﺘﮐ",
+	},
+	{
+		"Shaping persian word with farsi yeh",
+		"چای",
+		"ﯼﺎﭼ",
+	},
+	{
+		"Shaping urdu word with tteh",
+		"ٹوپی",
+		"ﯽﭘﻮﭨ",
+	},
+	{
+		"Shaping urdu word with heh goal and yeh barree",
+		"ہے",
+		"ﮯﮨ",
+	},
+	{
+		"Shaping kurdish word with reh with small v below",
+		"ڕۆژ",
+		"ﮊﯙڕ",
+	},
+	{
+		"Shaping kurdish word with ae",
+		"ئەو",
+		"ﻭەﺋ",
+	},
+	{
+		"Shaping kurdish word with veh",
+		"ڤیان",
+		"ﻥﺎﯿﭬ",
+	},
+	{
+		"Shaping alef wasla",
+		"ٱلْحَمْد",
+		"ﺪْﻤَﺤْﻟﭐ",
+	},
+	{
+		"Shaping non joining hamza",
+		"جزء",
+		"ﺀﺰﺟ",
+	},
+	{
+		"Shaping right joining hamza on waw",
+		"مسؤول",
+		"ﻝﻭﺆﺴﻣ",
+	},
+	{
+		"Shaping word with zero width non-joiner",
+		"می\u200cخواهم",
+		"ﻢﻫﺍﻮﺧ\u200cﯽﻣ",
+	},
+	{
+		"Shaping letter followed by zero width joiner",
+		"ب\u200d",
+		"\u200dﺑ",
+	},
+	{
+		"Shaping word with tatweel",
+		"بـــريد",
+		"ﺪﻳﺮـــﺑ",
+	},
+	{
+		"Shaping word with stacked shadda and fatha",
+		"مُحَمَّدٌ",
+		"ٌﺪَّﻤَﺤُﻣ",
+	},
+	{
+		"Shaping word with harakat around tatweel",
+		"كِتَـــابٌ",
+		"ٌﺏﺎـــَﺘِﻛ",
+	},
+	{
+		"Shaping lam alef ligature with shadda and fatha",
+		"إِلَّا",
+		"َّﻻِﺇ",
+	},
+	{
+		"Shaping word followed by arabic comma",
+		"الجامعة،",
+		"،ﺔﻌﻣﺎﺠﻟﺍ",
+	},
+	{
+		"Shaping word with quranic mark",
+		"بۖس",
+		"ﺲۖﺑ",
+	},
+	{
+		"Shaping isolated lam alef ligature",
+		"لا",
+		"ﻻ",
+	},
+	{
+		"Shaping final lam alef ligature",
+		"سلام",
+		"ﻡﻼﺳ",
+	},
+	{
+		"Shaping lam alef ligatures with hamza",
+		"الإسلام",
+		"ﻡﻼﺳﻹﺍ",
+	},
+	{
+		"Shaping lam alef ligature with madda",
+		"لآلئ",
+		"ﺊﻟﻵ",
+	},
+	{
+		"Shaping lam alef ligature with tashkeel between lam and alef",
+		"لَا",
+		"َﻻ",
+	},
+	{
+		"Shaping final lam alef ligature with tanwin",
+		"أهلًا",
+		"ًﻼﻫﺃ",
+	},
+}
+
+//unshapeTestCases contains all test cases for converting shaped text back to logical text
+var unshapeTestCases = []struct {
+	description string
+	input       string
+	expected    string
+}{
+	{
+		"Unshaping 1 word",
+		"ﻲﺑﺮﻌﻟﺎﺑ",
+		"بالعربي",
+	},
+	{
+		"Unshaping 1 word with tashkeel",
+		"ِﻞَﻣﻮَﺤَﻓ",
+		"فَحَومَلِ",
+	},
+	{
+		"Unshaping lam alef ligatures",
+		"ﻡﻼﺳﻹﺍ",
+		"الإسلام",
+	},
+	{
+		"Unshaping sentence with english words and numbers",
+		"ﻲﻫ (Multidimentional Array 2) ﺔﻓﻮﻔﺼﻤﻟﺍ",
+		"المصفوفة (Multidimentional Array 2) هي",
+	},
+	{
+		"Unshaping sentence with decimal number",
+		"ﻝﺎﻳﺭ 12.5 ﺮﻌﺴﻟﺍ",
+		"السعر 12.5 ريال",
+	},
+	{
+		"Unshaping persian letters",
+		"ﯼﺎﭼ",
+		"چای",
+	},
+	{
+		"Unshaping ligatures of words",
+		"ﷺ ﷲ",
+		"الله صلى الله عليه وسلم",
+	},
+	{
+		"Unshaping isolated harakat",
+		"ﹷ",
+		"ـَ",
+	},
+	{
+		"Unshaping spaced shadda ligatures",
+		"ﱢﺟ ﱠﺑ",
+		"بَّ جِّ",
+	},
+	{
+		"Unshaping harakat of lam alef ligatures",
+		"ﻡَﻼﺳ",
+		"سلَام",
+	},
+	{
+		"Unshaping multiple lines",
+		"ﻲﺑﺮﻋ ﺺﻧ\nﻲﻧﺎﺛ ﺮﻄﺳ",
+		"نص عربي\nسطر ثاني",
+	},
+}
+
+//justifyTestCases contains all test cases for kashida justification
+var justifyTestCases = []struct {
+	description string
+	input       string
+	width       int
+	expected    string
+}{
+	{
+		"Justifying after seen",
+		"بسم",
+		5,
+		"بســم",
+	},
+	{
+		"Justifying before final teh marbuta",
+		"مدرسة",
+		7,
+		"مدرســة",
+	},
+	{
+		"Justifying before final hae",
+		"وجه",
+		4,
+		"وجـه",
+	},
+	{
+		"Justifying before final alef",
+		"عالم",
+		6,
+		"عــالم",
+	},
+	{
+		"Justifying without splitting lam alef",
+		"سلام",
+		5,
+		"ســلام",
+	},
+	{
+		"Justifying before medial beh followed by yae",
+		"كبير",
+		5,
+		"كبـير",
+	},
+	{
+		"Justifying before final waw",
+		"نحو",
+		4,
+		"نحـو",
+	},
+	{
+		"Justifying other connections",
+		"كتب",
+		5,
+		"كتــب",
+	},
+	{
+		"Justifying after tatweel of the text",
+		"جميـل",
+		6,
+		"جميــل",
+	},
+	{
+		"Justifying word with harakat",
+		"كَتَبَ",
+		4,
+		"كَتَـبَ",
+	},
+	{
+		"Justifying sentence by priority",
+		"بسم الله الرحمن الرحيم",
+		25,
+		"بسـم الله الرحمـن الرحيـم",
+	},
+	{
+		"Justifying without stretching the name of God",
+		"بالله و اللَّهُمَّ",
+		20,
+		"بالله و اللَّهُمَّ",
+	},
+	{
+		"Justifying word with zero width joiner",
+		"سلام\u200d",
+		6,
+		"ســـلام\u200d",
+	},
+	{
+		"Justifying sentence evenly",
+		"السلام عليكم",
+		16,
+		"الســـلام عليكــم",
+	},
+	{
+		"Justifying sentence with english words",
+		"hello عالم",
+		12,
+		"hello عــالم",
+	},
+	{
+		"Justifying line wider than width",
+		"السلام عليكم",
+		5,
+		"السلام عليكم",
+	},
+	{
+		"Justifying line without connected letters",
+		"ورد",
+		10,
+		"ورد",
 	},
 }
 
+//shapeParagraphTestCases contains all test cases for breaking paragraphs into shaped lines
+var shapeParagraphTestCases = []struct {
+	description string
+	input       string
+	maxWidth    int
+	dir         Direction
+	expected    []string
+}{
+	{
+		"Breaking 1 line paragraph",
+		"السلام عليكم",
+		20,
+		RightToLeft,
+		[]string{"ﻢﻜﻴﻠﻋ ﻡﻼﺴﻟﺍ"},
+	},
+	{
+		"Breaking paragraph with tashkeel in logical order",
+		"قِفا نَبكِ مِن ذِكرى حَبيبٍ وَمَنزِلِ",
+		12,
+		RightToLeft,
+		[]string{"ﻦِﻣ ِﻚﺒَﻧ ﺎﻔِﻗ", "ٍﺐﻴﺒَﺣ ﻯﺮﻛِﺫ", "ِﻝِﺰﻨَﻣَﻭ"},
+	},
+	{
+		"Breaking paragraph with english words inside brackets",
+		"المصفوفة (Multidimentional Array) هي",
+		20,
+		RightToLeft,
+		[]string{"ﺔﻓﻮﻔﺼﻤﻟﺍ", "Multidimentional)", "ﻲﻫ (Array"},
+	},
+	{
+		"Breaking paragraph keeps arabic context of numbers",
+		"السعر الجديد 555-1234",
+		12,
+		RightToLeft,
+		[]string{"ﺪﻳﺪﺠﻟﺍ ﺮﻌﺴﻟﺍ", "1234-555"},
+	},
+	{
+		"Breaking paragraph keeps direction of its first letter",
+		"مرحبا hello world!",
+		12,
+		AutoDirection,
+		[]string{"hello ﺎﺒﺣﺮﻣ", "!world"},
+	},
+	{
+		"Breaking left to right paragraph",
+		"hello مرحبا world",
+		12,
+		LeftToRight,
+		[]string{"hello ﺎﺒﺣﺮﻣ", "world"},
+	},
+	{
+		"Breaking paragraphs on explicit newlines",
+		"بسم الله\nالرحمن الرحيم\r\nسطر",
+		40,
+		RightToLeft,
+		[]string{"ﻪﻠﻟﺍ ﻢﺴﺑ", "ﻢﻴﺣﺮﻟﺍ ﻦﻤﺣﺮﻟﺍ", "ﺮﻄﺳ"},
+	},
+	{
+		"Breaking paragraph with word wider than the line",
+		"المصفوفة هي",
+		4,
+		RightToLeft,
+		[]string{"ﺔﻓﻮﻔﺼﻤﻟﺍ", "ﻲﻫ"},
+	},
+	{
+		"Breaking empty paragraph",
+		"",
+		10,
+		RightToLeft,
+		[]string{""},
+	},
+}
+
+//clusterTestCases contains all test cases for the clusters of shaped text
+var clusterTestCases = []struct {
+	description string
+	input       string
+	expected    []Cluster
+}{
+	{
+		"Clustering stacked shadda and fatha",
+		"مَّد",
+		[]Cluster{
+			{Glyph: 'ﺪ', Start: 6, End: 8},
+			{Glyph: 'ﻣ', Marks: []rune{'َ', 'ّ'}, Start: 0, End: 6},
+		},
+	},
+	{
+		"Clustering harakat on lam alef ligature",
+		"لَاً",
+		[]Cluster{
+			{Glyph: 'ﻻ', Marks: []rune{'َ', 'ً'}, LamMarks: 1, Start: 0, End: 8},
+		},
+	},
+	{
+		"Clustering harakat after tatweel",
+		"كِتَـــابٌ",
+		[]Cluster{
+			{Glyph: 'ﺏ', Marks: []rune{'ٌ'}, Start: 16, End: 20},
+			{Glyph: 'ﺎ', Start: 14, End: 16},
+			{Glyph: 'ـ', Start: 12, End: 14},
+			{Glyph: 'ـ', Start: 10, End: 12},
+			{Glyph: 'ـ', Start: 8, End: 10},
+			{Glyph: 'ﺘ', Marks: []rune{'َ'}, Start: 4, End: 8},
+			{Glyph: 'ﻛ', Marks: []rune{'ِ'}, Start: 0, End: 4},
+		},
+	},
+	{
+		"Clustering mixed text with mirrored brackets",
+		"(عَ) a",
+		[]Cluster{
+			{Glyph: 'a', Start: 7, End: 8},
+			{Glyph: ' ', Start: 6, End: 7},
+			{Glyph: '(', Start: 5, End: 6},
+			{Glyph: 'ﻉ', Marks: []rune{'َ'}, Start: 1, End: 5},
+			{Glyph: ')', Start: 0, End: 1},
+		},
+	},
+	{
+		"Clustering marks of latin letters",
+		"c\u0301a",
+		[]Cluster{
+			{Glyph: 'c', Marks: []rune{'\u0301'}, Start: 0, End: 3},
+			{Glyph: 'a', Start: 3, End: 4},
+		},
+	},
+}
+
+//terminalTestCases contains all test cases for shaping text for terminals
+var terminalTestCases = []struct {
+	description string
+	input       string
+	opts        TerminalOptions
+	expected    string
+}{
+	{
+		"Shaping a right to left line",
+		"السلام عليكم",
+		TerminalOptions{},
+		"ﻢﻜﻴﻠﻋ ﻡﻼﺴﻟﺍ",
+	},
+	{
+		"Dropping harakat",
+		"مَرْحَبًا",
+		TerminalOptions{},
+		"ﺎﺒﺣﺮﻣ",
+	},
+	{
+		"Keeping harakat after their glyphs",
+		"مَرْحَبًا",
+		TerminalOptions{KeepHarakat: true},
+		"ﺎﺒًﺣَﺮْﻣَ",
+	},
+	{
+		"Shaping each line on its own",
+		"سطر أول\r\nسطر ثاني",
+		TerminalOptions{},
+		"ﻝﻭﺃ ﺮﻄﺳ\r\nﻲﻧﺎﺛ ﺮﻄﺳ",
+	},
+	{
+		"Keeping the order of tab separated cells",
+		"الاسم\tالعمر\nأحمد\t30",
+		TerminalOptions{},
+		"ﻢﺳﻻﺍ\tﺮﻤﻌﻟﺍ\nﺪﻤﺣﺃ\t30",
+	},
+	{
+		"Detecting left to right lines",
+		"Name: أحمد",
+		TerminalOptions{},
+		"Name: ﺪﻤﺣﺃ",
+	},
+	{
+		"Laying out left to right text in a right to left line",
+		"Name: أحمد",
+		TerminalOptions{Direction: RightToLeft},
+		"ﺪﻤﺣﺃ :Name",
+	},
+	{
+		"Dropping bidi controls",
+		"\u202bعربي\u202c",
+		TerminalOptions{},
+		"ﻲﺑﺮﻋ",
+	},
+}
+
+//displayWidthTestCases contains all test cases for the terminal width of shaped text
+var displayWidthTestCases = []struct {
+	description string
+	input       string
+	expected    int
+}{
+	{"Counting latin letters", "Name", 4},
+	{"Counting shaped letters", "ﺎﺒﺣﺮﻣ", 5},
+	{"Harakat are zero width", "ﺎﺒًﺣَﺮْﻣَ", 5},
+	{"Lam alef ligature is one cell", "ﻻ", 1},
+	{"Format characters are zero width", "a\u200db\u202c", 2},
+}
+
+//shapeWithDirectionTestCases contains all test cases for shaping text with a base direction
+var shapeWithDirectionTestCases = []struct {
+	description string
+	input       string
+	direction   Direction
+	expected    string
+}{
+	{
+		"Shaping arabic word in a left to right sentence",
+		"Hello عربي!",
+		LeftToRight,
+		"Hello ﻲﺑﺮﻋ!",
+	},
+	{
+		"Shaping arabic word in parentheses in a left to right sentence",
+		"abc (عربي) def",
+		LeftToRight,
+		"abc (ﻲﺑﺮﻋ) def",
+	},
+	{
+		"Detecting right to left direction from the first strong letter",
+		"123 عربي",
+		AutoDirection,
+		"ﻲﺑﺮﻋ 123",
+	},
+	{
+		"Detecting left to right direction from the first strong letter",
+		"The word مرحبا means hello.",
+		AutoDirection,
+		"The word ﺎﺒﺣﺮﻣ means hello.",
+	},
+	{
+		"Shaping english in parentheses in a right to left sentence",
+		"عربي (English) عربي",
+		RightToLeft,
+		"ﻲﺑﺮﻋ (English) ﻲﺑﺮﻋ",
+	},
+}
+
+//reorderTestCases contains all test cases for the bidirectional reordering
+var reorderTestCases = []struct {
+	description string
+	input       string
+	direction   Direction
+	expected    string
+}{
+	{
+		"Right to left override",
+		"\u202eabc\u202c d",
+		LeftToRight,
+		"\u202e\u202ccba d",
+	},
+	{
+		"Right to left isolate",
+		"ab \u2067جد هـ\u2069 و",
+		LeftToRight,
+		"ab \u2067ـه دج\u2069 و",
+	},
+	{
+		"First strong isolate",
+		"a \u2068ب ج\u2069 d",
+		LeftToRight,
+		"a \u2068ج ب\u2069 d",
+	},
+	{
+		"Right to left embedding of left to right text",
+		"a \u202bb c\u202c d",
+		LeftToRight,
+		"a \u202bb c\u202c d",
+	},
+	{
+		"European numbers after arabic letters are arabic numbers",
+		"ا 1+2 ب",
+		LeftToRight,
+		"ب 2+1 ا",
+	},
+	{
+		"Trailing punctuation takes the paragraph direction",
+		"ا ب.",
+		AutoDirection,
+		".ب ا",
+	},
+	{
+		"Trailing whitespace stays at the end of the line",
+		"ا ب  ",
+		LeftToRight,
+		"ب ا  ",
+	},
+	{
+		"Numbers after an embedding keep their order",
+		"\u202aא\u202c1 2",
+		LeftToRight,
+		"\u202a\u202cא1 2",
+	},
+}
+
+//bidiCharacterTestCases contains cases in the format of BidiCharacterTest.txt: the code points, the paragraph
+//direction (0 left to right, 1 right to left, 2 auto), the resolved paragraph level, the resolved levels
+//with x for the characters removed by X9 and the visual order of the characters that aren't removed
+var bidiCharacterTestCases = []struct {
+	description string
+	line        string
+}{
+	{"Left to right embedding in a left to right paragraph", "202A 05D0 202C 0031 0020 0032;0;0;x 3 x 0 0 0;1 3 4 5"},
+	{"Right to left embedding of left to right text", "0061 202B 0062 0020 0063 202C 0064;2;0;0 x 2 2 2 x 0;0 2 3 4 6"},
+	{"Left to right embedding next to numbers", "05D0 202A 0062 202C 0031;1;1;1 x 2 x 2;2 4 0"},
+	{"Left to right override", "202D 05D0 05D1 202C 0020 05D2;0;0;x 2 2 x 0 1;1 2 4 5"},
+	{"Right to left override", "202E 0061 0062 202C 0020 0063;2;0;x 1 1 x 0 0;2 1 4 5"},
+	{"Nested embeddings", "0061 202B 202A 0031 202C 202C 0032;0;0;0 x x 2 x x 0;0 3 6"},
+	{"Embeddings of numbers", "202B 0031 202C 202A 0661 202C;1;1;x 4 x x 4 x;1 4"},
+	{"Right to left isolate", "0061 0020 2067 05D1 0020 0031 2069 0020 0063;0;0;0 0 0 1 1 2 0 0 0;0 1 2 5 4 3 6 7 8"},
+	{"Left to right isolate", "05D0 0020 2066 0062 0020 0031 2069 0020 05D2;1;1;1 1 1 2 2 2 1 1 1;8 7 6 3 4 5 2 1 0"},
+	{"First strong isolate", "2068 05D1 0020 0061 2069 0020 0063;2;0;0 1 1 2 0 0 0;0 3 2 1 4 5 6"},
+	{"Empty first strong isolate", "0031 2068 2069 05D0;2;1;2 1 1 1;3 2 1 0"},
+	{"Unmatched isolate", "0061 2067 0628 0020 0063;0;0;0 0 1 1 2;0 1 4 3 2"},
+	{"Adjacent isolates", "0061 2067 0628 2069 2066 0063 2069 0628;1;1;2 1 3 1 1 2 1 1;7 6 5 4 3 2 1 0"},
+	{"Unmatched isolate terminator in an override", "202E 0061 2069 0062 202C;0;0;x 1 1 1 x;3 2 1"},
+	{"Boundary neutrals", "0061 200B 05D1 200B 0031;0;0;0 x 1 x 2;0 4 2"},
+	{"Boundary neutrals between numbers", "05D0 00AD 0031 200B 0032;1;1;1 x 2 x 2;2 4 0"},
+	{"Separator between numbers with boundary neutrals", "0031 200B 002B 200B 0032;1;1;2 x 2 x 2;0 2 4"},
+	{"Bracket pair with a mark", "0061 0028 05D1 0029 0651 0063;0;0;0 0 1 0 0 0;0 1 2 3 4 5"},
+	{"Bracket pair with a mark in a right to left paragraph", "05D0 0028 0062 0029 0651;1;1;1 1 2 1 1;4 3 2 1 0"},
+	{"Square brackets with a mark", "05D0 005B 0062 005D 064E 0020 05D2;0;0;1 0 0 0 0 0 1;0 1 2 3 4 5 6"},
+	{"Overlapping bracket pairs", "0061 0028 05D1 005B 05D2 0029 05D3 005D;1;1;2 1 1 1 1 1 1 1;7 6 5 4 3 2 1 0"},
+	{"Bracket pair with marks", "0028 05D0 0029 0651 0651 0062;0;0;0 1 0 0 0 0;0 1 2 3 4 5"},
+	{"Bracket pair of arabic numbers", "0628 0028 0031 0029;2;1;1 1 2 1;3 2 1 0"},
+	{"Bracket pair of numbers", "0061 0028 0031 0029 0628;1;1;2 2 2 2 1;4 0 1 2 3"},
+	{"Canonically equivalent brackets", "2329 05D0 3009;0;0;0 1 0;0 1 2"},
+	{"Bracket pair in an embedding", "0061 202B 0028 0062 0029 202C 0651;0;0;0 x 1 2 1 x 1;0 6 4 3 2"},
+	{"Bracket pair around an isolate", "0028 2067 05D0 2069 0029 0651;0;0;0 0 1 0 0 0;0 1 2 3 4 5"},
+	{"Unpaired brackets", "0029 05D0 0028;0;0;0 1 0;0 1 2"},
+	{"Bracket pair with a boundary neutral", "0061 0028 05D1 200B 0029 0651;0;0;0 0 1 x 0 0;0 1 2 4 5"},
+	{"Bracket pair around an override", "0031 0028 202E 05D0 202C 0029;2;1;2 1 x 3 x 1;5 3 1 0"},
+}
+
+//joiningTypeTestCases contains all test cases for the joining types of letters
+var joiningTypeTestCases = []struct {
+	description string
+	input       rune
+	expected    joiningType
+}{
+	{"Beh is dual joining", 'ب', dualJoining},
+	{"Alef is right joining", 'ا', rightJoining},
+	{"Hamza is non joining", 'ء', nonJoining},
+	{"Tatweel is join causing", 'ـ', joinCausing},
+	{"Zero width joiner is join causing", '\u200D', joinCausing},
+	{"Zero width non-joiner is non joining", '\u200C', nonJoining},
+	{"Fathah is transparent", 'َ', transparent},
+	{"Quranic marks are transparent", 'ۖ', transparent},
+	{"Arabic comma is non joining", '،', nonJoining},
+	{"Farsi yeh is dual joining", 'ی', dualJoining},
+	{"Arabic supplement letters have joining types", 'ݙ', rightJoining},
+	{"Latin letters are non joining", 'a', nonJoining},
+}
+
 //arabicLetterTestCases
 var arabicLetterTestCases = []struct {
 	description string
@@ -271,6 +1606,11 @@ var arabicLetterTestCases = []struct {
 		'ص',
 		true,
 	},
+	{
+		"Checking arabic supplement letter",
+		'ݐ',
+		true,
+	},
 	{
 		"Checking english letter",
 		's',
@@ -0,0 +1,86 @@
+package garabic
+
+import (
+	"math"
+	"unicode"
+)
+
+//Cluster is a glyph of shaped text with the combining marks drawn over it, renderers draw the glyph
+//then position the marks over it instead of relying on the order of the runes in the shaped text
+type Cluster struct {
+	//Glyph is the shaped letter, lam alef ligature or other character of the cluster
+	Glyph rune
+	//Marks are the harakat and other combining marks of the glyph in logical order
+	Marks []rune
+	//LamMarks is the number of marks drawn over the lam when Glyph is a lam alef ligature,
+	//Marks[:LamMarks] belong to the lam and the rest to the alef
+	LamMarks int
+	//Start and End are the byte offsets of the letters and marks of the cluster in the input
+	Start, End int
+}
+
+//ShapeClusters shapes the text like Shape and returns its clusters in visual order
+func ShapeClusters(input string) []Cluster {
+	return ShapeClustersWithDirection(input, RightToLeft)
+}
+
+//ShapeClustersWithDirection shapes the text like ShapeWithDirection and returns its clusters in visual order,
+//the lines of the text follow each other and their newlines are left out
+func ShapeClustersWithDirection(input string, dir Direction) []Cluster {
+	var clusters []Cluster
+	for _, line := range shapeLines(input, math.MaxInt32, nil, dir) {
+		clusters = append(clusters, line.clusters()...)
+	}
+	return clusters
+}
+
+//ShapeParagraphClusters breaks the text into lines like ShapeParagraphWithDirection and returns the clusters
+//of each line in visual order
+func ShapeParagraphClusters(text string, maxWidth int, measure func(string) int, dir Direction) [][]Cluster {
+	var lines [][]Cluster
+	for _, line := range shapeLines(text, maxWidth, measure, dir) {
+		lines = append(lines, line.clusters())
+	}
+	return lines
+}
+
+//clusters groups the letters of the line with the marks following them in logical order
+//and returns the clusters in visual order
+func (l shapedLine) clusters() []Cluster {
+	logical := make([]Cluster, 0, l.end-l.start)
+	//owner is the logical cluster of each rune of the line
+	owner := make([]int, l.end-l.start)
+	split := 0
+	for i := l.start; i < l.end; i++ {
+		letter := l.letters[i]
+		if isMark(letter.shape) && len(logical) > 0 {
+			cluster := &logical[len(logical)-1]
+			cluster.Marks = append(cluster.Marks, letter.shape)
+			if letter.start < split {
+				cluster.LamMarks++
+			}
+			if letter.end > cluster.End {
+				cluster.End = letter.end
+			}
+			owner[i-l.start] = len(logical) - 1
+			continue
+		}
+		split = letter.split
+		logical = append(logical, Cluster{Glyph: l.paragraph.visualRune(i), Start: letter.start, End: letter.end})
+		owner[i-l.start] = len(logical) - 1
+	}
+
+	//Clusters take the position of their glyph, marks share the embedding level of their glyph
+	visual := make([]Cluster, 0, len(logical))
+	for _, i := range l.paragraph.visualOrder(l.start, l.end) {
+		if i == l.start || owner[i-l.start] != owner[i-l.start-1] {
+			visual = append(visual, logical[owner[i-l.start]])
+		}
+	}
+	return visual
+}
+
+//isMark checks if the rune is a combining mark drawn over another letter
+func isMark(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
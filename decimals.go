@@ -0,0 +1,199 @@
+package garabic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//Errors returned when spelling decimals and fractions
+var (
+	//ErrInvalidNumber is returned when a decimal number can't be read
+	ErrInvalidNumber = errors.New("garabic: invalid number")
+	//ErrZeroDenominator is returned for fractions with a zero denominator
+	ErrZeroDenominator = errors.New("garabic: zero denominator")
+)
+
+//_fractions are the names of the parts from the half to the tenth, they are counted like nouns
+var _fractions = []Noun{
+	{},
+	{},
+	{"نصف", "نصفان", "أنصاف", "نصفًا", false},
+	{"ثلث", "ثلثان", "أثلاث", "ثلثًا", false},
+	{"ربع", "ربعان", "أرباع", "ربعًا", false},
+	{"خمس", "خمسان", "أخماس", "خمسًا", false},
+	{"سدس", "سدسان", "أسداس", "سدسًا", false},
+	{"سبع", "سبعان", "أسباع", "سبعًا", false},
+	{"ثمن", "ثمنان", "أثمان", "ثمنًا", false},
+	{"تسع", "تسعان", "أتساع", "تسعًا", false},
+	{"عشر", "عشران", "أعشار", "عشرًا", false},
+}
+
+//DecimalStyle is the way the decimals of a number are read
+type DecimalStyle int
+
+const (
+	//DecimalPoint reads the decimals as a number after the point, leading zeros are read one by one,
+	//e.g. ثلاثة فاصلة أربعة عشر
+	DecimalPoint DecimalStyle = iota
+	//DecimalParts reads the decimals as parts of a power of ten, e.g. ثلاثة و أربعة عشر من مئة
+	DecimalParts
+)
+
+//DecimalOptions controls how decimal numbers are spelled
+type DecimalOptions struct {
+	//Precision is the maximum number of decimals, the number is rounded to it.
+	//All the decimals are spelled when it's zero
+	Precision int
+	//Style is the way the decimals are read
+	Style DecimalStyle
+}
+
+//SpellDecimal will transform a decimal number like 3.14 into a readable arabic version,
+//trailing zeros of the decimals aren't spelled. Arabic digits and the arabic decimal separator ٫ are accepted,
+//ErrInvalidNumber is returned when it isn't a number
+func SpellDecimal(input string, opts DecimalOptions) (string, error) {
+	negative, whole, fraction, ok := splitDecimal(input)
+	if !ok {
+		return "", ErrInvalidNumber
+	}
+	if opts.Precision > 0 && len(fraction) > opts.Precision {
+		rounded, _ := new(big.Rat).SetString(whole + "." + fraction)
+		_, whole, fraction, _ = splitDecimal(rounded.FloatString(opts.Precision))
+	}
+	fraction = strings.TrimRight(fraction, "0")
+
+	integer, _ := new(big.Int).SetString(whole, 10)
+	spelled, err := SpellBigInt(integer)
+	if err != nil {
+		return "", err
+	}
+	if fraction != "" {
+		decimals, err := spellDecimals(fraction, opts.Style)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case opts.Style == DecimalPoint:
+			spelled = fmt.Sprintf("%s فاصلة %s", spelled, decimals)
+		case integer.Sign() == 0:
+			//Parts of a number lower than one are read alone
+			spelled = decimals
+		default:
+			spelled = fmt.Sprintf("%s و %s", spelled, decimals)
+		}
+	}
+	if negative && (integer.Sign() > 0 || fraction != "") {
+		spelled = "سالب " + spelled
+	}
+	return spelled, nil
+}
+
+//SpellFloat will transform a float into a readable arabic version like SpellDecimal,
+//ErrInvalidNumber is returned for infinities and NaN
+func SpellFloat(input float64, opts DecimalOptions) (string, error) {
+	if math.IsInf(input, 0) || math.IsNaN(input) {
+		return "", ErrInvalidNumber
+	}
+	return SpellDecimal(strconv.FormatFloat(input, 'f', -1, 64), opts)
+}
+
+//SpellPercent will transform a decimal number into a readable arabic percentage,
+//e.g. 25 is spelled as خمسة و عشرون بالمئة
+func SpellPercent(input string, opts DecimalOptions) (string, error) {
+	spelled, err := SpellDecimal(input, opts)
+	if err != nil {
+		return "", err
+	}
+	return spelled + " بالمئة", nil
+}
+
+//SpellFraction will transform a fraction into a readable arabic version. The parts from the half
+//to the tenth are named, e.g. ربع and ثلاثة أرباع, larger denominators are read as سبعة من خمسة عشر.
+//The whole part of improper fractions is spelled first, e.g. واحد و نصف
+func SpellFraction(numerator, denominator int) (string, error) {
+	if denominator == 0 {
+		return "", ErrZeroDenominator
+	}
+	negative := (numerator < 0) != (denominator < 0) && numerator != 0
+	num, den := magnitude(int64(numerator)), magnitude(int64(denominator))
+
+	var parts []string
+	if whole := num / den; whole > 0 || num == 0 {
+		parts = append(parts, SpellUint64(whole))
+	}
+	switch rest := num % den; {
+	case rest == 0:
+	case den <= 10 && rest == 1:
+		parts = append(parts, _fractions[den].Singular)
+	case den <= 10:
+		parts = append(parts, spellQuantity(digitGroups(rest), false, _fractions[den], Nominative))
+	default:
+		parts = append(parts, fmt.Sprintf("%s من %s", SpellUint64(rest), spellGroups(digitGroups(den), false, SpellOptions{Case: Genitive}, false)))
+	}
+
+	spelled := strings.Join(parts, " و ")
+	if negative {
+		spelled = "سالب " + spelled
+	}
+	return spelled, nil
+}
+
+//magnitude returns the absolute value of a number, the magnitude of math.MinInt64 only fits in an uint64
+func magnitude(number int64) uint64 {
+	if number < 0 {
+		return uint64(-number)
+	}
+	return uint64(number)
+}
+
+//spellDecimals spells the decimals of a number in the style
+func spellDecimals(fraction string, style DecimalStyle) (string, error) {
+	digits, _ := new(big.Int).SetString(fraction, 10)
+	spelled, err := SpellBigInt(digits)
+	if err != nil {
+		return "", err
+	}
+	if style == DecimalParts {
+		parts, err := SpellBigInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(fraction))), nil))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s من %s", spelled, parts), nil
+	}
+	zeros := strings.Repeat(_zeroToNine[0]+" ", len(fraction)-len(strings.TrimLeft(fraction, "0")))
+	return zeros + spelled, nil
+}
+
+//splitDecimal splits a decimal number into its sign, whole part and decimals,
+//arabic digits and the arabic decimal separator ٫ are converted and the whole part is "0" when it's left out
+func splitDecimal(input string) (negative bool, whole, fraction string, ok bool) {
+	input = strings.TrimSpace(strings.ReplaceAll(ToEnglishDigits(input), "٫", "."))
+	negative = strings.HasPrefix(input, "-")
+	input = strings.TrimPrefix(input, "-")
+
+	whole = input
+	if i := strings.IndexByte(input, '.'); i >= 0 {
+		whole, fraction = input[:i], input[i+1:]
+	}
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return false, "", "", false
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	return negative, whole, fraction, true
+}
+
+//isDigits checks if the string is made of ascii digits only
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
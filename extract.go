@@ -0,0 +1,260 @@
+package garabic
+
+import (
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//NumberKind is the way a number is written in text
+type NumberKind int
+
+const (
+	//NumberDigits is a number written in digits, e.g. 1,234.5 or ١٬٢٣٤٫٥
+	NumberDigits NumberKind = iota
+	//NumberWords is a number spelled in words, e.g. ثلاثة آلاف و خمسمئة
+	NumberWords
+	//NumberMixed is a number written in digits followed by a scale word and maybe more words, e.g. 3 ملايين,
+	//٢٫٥ مليار or 3 آلاف و خمسمئة
+	NumberMixed
+)
+
+//NumberSpan is a number found in text by ExtractNumbers
+type NumberSpan struct {
+	//Kind is the way the number is written
+	Kind NumberKind
+	//Start and End are the byte offsets of the number in the text
+	Start, End int
+	//Text is the number as written in the text
+	Text string
+	//Value is the exact value of the number, decimals included
+	Value *big.Rat
+}
+
+//ExtractNumbers finds the numbers in running text in the order they're written. Western, arabic-indic and
+//extended arabic-indic digits are read with the decimal separators . and ٫ and the thousands separators , and ٬
+//between groups of three digits. Numbers spelled in words are read like ParseNumber, punctuation ends them
+//and a word that can't follow the words before it starts a new number, e.g. خمسة ستة are two numbers
+func ExtractNumbers(text string) []NumberSpan {
+	var spans []NumberSpan
+	for _, run := range numberRuns(text) {
+		//end is the next number in digits, words are read up to it
+		end := 0
+		for i := 0; i < len(run); {
+			if end <= i {
+				for end = i + 1; end < len(run); end++ {
+					if _, ok := parseDigitNumber(run[end].text); ok {
+						break
+					}
+				}
+			}
+			span, read := readNumber(run[i:end])
+			if read == 0 {
+				i++
+				continue
+			}
+			span.Start, span.End = run[i].start, run[i+read-1].end
+			span.Text = text[span.Start:span.End]
+			spans = append(spans, span)
+			i += read
+		}
+	}
+	return spans
+}
+
+//readNumber reads the number at the start of the tokens and returns the number of tokens read,
+//no tokens are read when the tokens don't start with a number. Only the first token can be a number in digits
+func readNumber(tokens []numberToken) (NumberSpan, int) {
+	if value, ok := parseDigitNumber(tokens[0].text); ok {
+		//Only a scale word can follow digits, e.g. 3 ملايين
+		if len(tokens) > 1 {
+			if word, ok := _numberWords[Normalize(tokens[1].text)]; ok && word.kind == scaleWord {
+				//The words from the scale on are read like ParseNumber with one in place of the digits,
+				//e.g. 3 آلاف و خمسمئة is read as 3 × 1000 + (1 آلاف و خمسمئة - 1000)
+				rest, read := parseNumberTokens(append([]numberToken{{text: "1"}}, tokens[1:]...))
+				if read > 1 {
+					scale := new(big.Int).Exp(big.NewInt(1000), big.NewInt(word.value), nil)
+					rest.Sub(rest, scale)
+					if value.Sign() < 0 {
+						rest.Neg(rest)
+					}
+					value.Mul(value, new(big.Rat).SetInt(scale))
+					return NumberSpan{Kind: NumberMixed, Value: value.Add(value, new(big.Rat).SetInt(rest))}, read
+				}
+			}
+		}
+		return NumberSpan{Kind: NumberDigits, Value: value}, 1
+	}
+
+	if word, ok := lookupNumberWord(tokens[0].text); !ok || word.kind == conjunctionWord {
+		return NumberSpan{}, 0
+	}
+	value, read := parseNumberTokens(tokens)
+	if read == 0 {
+		return NumberSpan{}, 0
+	}
+	return NumberSpan{Kind: NumberWords, Value: new(big.Rat).SetInt(value)}, read
+}
+
+//numberRuns splits the text into runs of words and numbers in digits separated by spaces,
+//the other characters like punctuation end a run
+func numberRuns(text string) [][]numberToken {
+	var runs [][]numberToken
+	var run []numberToken
+	//prev is the rune before the current token, a sign is only read at the start of a word
+	prev := ' '
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case scanSignedDigits(text[i:]) > 0 && (isDigit(r) || !unicode.IsLetter(prev) && !isDigit(prev)):
+			end := i + scanSignedDigits(text[i:])
+			run = append(run, numberToken{text: text[i:end], start: i, end: end})
+			i = end
+		case unicode.IsLetter(r) || isMark(r):
+			end := i
+			for end < len(text) {
+				r, size := utf8.DecodeRuneInString(text[end:])
+				if !unicode.IsLetter(r) && !isMark(r) {
+					break
+				}
+				end += size
+			}
+			run = appendWordToken(run, text, i, end)
+			i = end
+		default:
+			if len(run) > 0 {
+				runs = append(runs, run)
+				run = nil
+			}
+			i += size
+		}
+		prev, _ = utf8.DecodeLastRuneInString(text[:i])
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+//appendWordToken appends the word between start and end to the tokens, a و attached to a word
+//that isn't a number word is split from it with its harakat
+func appendWordToken(tokens []numberToken, text string, start, end int) []numberToken {
+	word := text[start:end]
+	if _, ok := lookupNumberWord(word); !ok && strings.HasPrefix(word, "و") {
+		split := start + len("و")
+		for split < end {
+			r, size := utf8.DecodeRuneInString(text[split:])
+			if !isMark(r) {
+				break
+			}
+			split += size
+		}
+		if split < end {
+			tokens = append(tokens, numberToken{text: text[start:split], start: start, end: split})
+			start = split
+		}
+	}
+	return append(tokens, numberToken{text: text[start:end], start: start, end: end})
+}
+
+//scanSignedDigits returns the length of the number in digits at the start of the text with its sign,
+//it's zero when the text doesn't start with a number
+func scanSignedDigits(text string) int {
+	sign := 0
+	if r, size := utf8.DecodeRuneInString(text); r == '-' || r == '+' || r == '−' {
+		sign = size
+	}
+	if digits := scanDigits(text[sign:]); digits > 0 {
+		return sign + digits
+	}
+	return 0
+}
+
+//scanDigits returns the length of the number in digits at the start of the text. A thousands separator is
+//only read between groups of three digits and a decimal separator only before a digit, the number ends before
+//any other separator
+func scanDigits(text string) int {
+	//digits is the length of the current group, grouped is set after a thousands separator
+	end, digits := 0, 0
+	grouped, decimal := false, false
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if isDigit(r) {
+			digits++
+			end += size
+			continue
+		}
+		next := end + size
+		switch {
+		case digits == 0:
+			return end
+		case isThousandsSeparator(r) && !decimal && (digits == 3 || !grouped && digits < 3) && isDigitGroup(text[next:]):
+			grouped = true
+		case isDecimalSeparator(r) && !decimal && isDigit(firstRune(text[next:])):
+			decimal = true
+		default:
+			return end
+		}
+		digits = 0
+		end = next
+	}
+	return end
+}
+
+//isDigitGroup checks if the text starts with a group of three digits that isn't followed by another digit
+func isDigitGroup(text string) bool {
+	for i := 0; i < 3; i++ {
+		r, size := utf8.DecodeRuneInString(text)
+		if !isDigit(r) {
+			return false
+		}
+		text = text[size:]
+	}
+	return !isDigit(firstRune(text))
+}
+
+//firstRune returns the first rune of the text, or utf8.RuneError when it's empty
+func firstRune(text string) rune {
+	r, _ := utf8.DecodeRuneInString(text)
+	return r
+}
+
+//parseDigitNumber reads a number in western, arabic-indic or extended arabic-indic digits with its sign
+//and separators, the whole token has to be the number
+func parseDigitNumber(token string) (*big.Rat, bool) {
+	if token == "" || scanSignedDigits(token) != len(token) {
+		return nil, false
+	}
+	var number strings.Builder
+	for _, r := range token {
+		switch {
+		case isDigit(r):
+			digit, _ := digitValue(r)
+			number.WriteByte(byte('0' + digit))
+		case isDecimalSeparator(r):
+			number.WriteByte('.')
+		case r == '-' || r == '−':
+			number.WriteByte('-')
+		}
+	}
+	return new(big.Rat).SetString(number.String())
+}
+
+//isDigit checks if the rune is a western, arabic-indic or extended arabic-indic digit
+func isDigit(r rune) bool {
+	_, ok := digitValue(r)
+	return ok
+}
+
+//isDecimalSeparator checks if the rune is the western or the arabic decimal separator
+func isDecimalSeparator(r rune) bool {
+	return r == '.' || r == '٫'
+}
+
+//isThousandsSeparator checks if the rune is the western or the arabic thousands separator
+func isThousandsSeparator(r rune) bool {
+	return r == ',' || r == '٬'
+}
@@ -0,0 +1,141 @@
+package garabic
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//Locale holds the digits and separators used to write numbers in a region
+type Locale struct {
+	//Digits are the digits from zero to nine
+	Digits [10]rune
+	//Decimal is the decimal separator
+	Decimal rune
+	//Thousands is the separator between groups of three digits, numbers aren't grouped when it's zero
+	Thousands rune
+	//Percent is the percent sign written after the number
+	Percent rune
+}
+
+//Locales writing numbers in arabic
+var (
+	//LocaleGulf writes numbers with arabic-indic digits and the arabic separators, e.g. ١٬٢٣٤٫٥
+	LocaleGulf = Locale{
+		Digits:    [10]rune{'٠', '١', '٢', '٣', '٤', '٥', '٦', '٧', '٨', '٩'},
+		Decimal:   '٫',
+		Thousands: '٬',
+		Percent:   '٪',
+	}
+	//LocaleEgypt writes numbers with arabic-indic digits and the arabic separators like LocaleGulf
+	LocaleEgypt = LocaleGulf
+	//LocaleMaghreb writes numbers with western digits, a decimal comma and a thousands dot, e.g. 1.234,5
+	LocaleMaghreb = Locale{
+		Digits:    [10]rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'},
+		Decimal:   ',',
+		Thousands: '.',
+		Percent:   '%',
+	}
+)
+
+//FormatInt writes an integer with the digits and thousands separator of the locale, e.g. 1234 is written
+//as ١٬٢٣٤ in LocaleGulf
+func FormatInt(input int64, locale Locale) string {
+	return localizeNumber(strconv.FormatInt(input, 10), locale)
+}
+
+//FormatFloat writes a float with the digits and separators of the locale rounded to precision decimals,
+//the smallest number of decimals that reads back the same float is used when precision is -1
+func FormatFloat(input float64, precision int, locale Locale) string {
+	return localizeNumber(strconv.FormatFloat(input, 'f', precision, 64), locale)
+}
+
+//FormatPercent writes a percentage like FormatFloat followed by the percent sign of the locale,
+//e.g. 12.5 is written as ١٢٫٥٪ in LocaleGulf
+func FormatPercent(input float64, precision int, locale Locale) string {
+	return FormatFloat(input, precision, locale) + string(locale.Percent)
+}
+
+//ParseFormattedInt reads an integer written like FormatInt, digits of any script are accepted.
+//ErrInvalidNumber is returned when it isn't an integer, ErrNumberTooLarge when it doesn't fit in an int64
+func ParseFormattedInt(input string, locale Locale) (int64, error) {
+	number, ok := readFormatted(input, locale, false)
+	if !ok || !number.IsInt() {
+		return 0, ErrInvalidNumber
+	}
+	if !number.Num().IsInt64() {
+		return 0, ErrNumberTooLarge
+	}
+	return number.Num().Int64(), nil
+}
+
+//ParseFormattedFloat reads a number written like FormatFloat or FormatPercent, digits of any script are accepted.
+//The arabic separators ٫ and ٬ are read in every locale, and the western . and , in the locales using the arabic
+//separators. A percentage is read as written, e.g. ٢٥٪ is read as 25. ErrInvalidNumber is returned when
+//it isn't a number
+func ParseFormattedFloat(input string, locale Locale) (float64, error) {
+	number, ok := readFormatted(input, locale, true)
+	if !ok {
+		return 0, ErrInvalidNumber
+	}
+	value, _ := number.Float64()
+	return value, nil
+}
+
+//localizeNumber replaces the digits and separators of a number formatted by strconv with the ones of the locale
+func localizeNumber(number string, locale Locale) string {
+	whole, fraction := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		whole, fraction = number[:i], number[i+1:]
+	}
+	var output strings.Builder
+	if strings.HasPrefix(whole, "-") {
+		output.WriteByte('-')
+		whole = whole[1:]
+	}
+	if !isDigits(whole) {
+		//Infinities and NaN are kept as they are
+		return number
+	}
+	for i := 0; i < len(whole); i++ {
+		if i > 0 && (len(whole)-i)%3 == 0 && locale.Thousands != 0 {
+			output.WriteRune(locale.Thousands)
+		}
+		output.WriteRune(locale.Digits[whole[i]-'0'])
+	}
+	if fraction != "" {
+		output.WriteRune(locale.Decimal)
+		for i := 0; i < len(fraction); i++ {
+			output.WriteRune(locale.Digits[fraction[i]-'0'])
+		}
+	}
+	return output.String()
+}
+
+//readFormatted reads a number written with the separators of the locale, spaces around the number and
+//bidi marks are ignored and a percent sign is accepted after it when percent is set
+func readFormatted(input string, locale Locale, percent bool) (*big.Rat, bool) {
+	var token strings.Builder
+	for _, r := range strings.TrimSpace(input) {
+		switch {
+		case unicode.Is(unicode.Cf, r):
+		case r == locale.Decimal:
+			token.WriteRune('٫')
+		case r == locale.Thousands:
+			token.WriteRune('٬')
+		default:
+			token.WriteRune(r)
+		}
+	}
+	number := token.String()
+	if percent {
+		for _, sign := range []string{string(locale.Percent), "%", "٪"} {
+			if strings.HasSuffix(number, sign) {
+				number = strings.TrimSuffix(number, sign)
+				break
+			}
+		}
+	}
+	return parseDigitNumber(strings.TrimSpace(number))
+}
@@ -2,22 +2,14 @@
 package garabic
 
 import (
-	"bytes"
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
-	"golang.org/x/text/runes"
 	"golang.org/x/text/transform"
 )
 
-//letterGroup represents the letter and bounding letters
-type letterGroup struct {
-	backLetter  rune
-	letter      rune
-	frontLetter rune
-}
-
 //letterShape represents all shapes of arabic letters in a word
 // https://web.stanford.edu/dept/lc/arabic/alphabet/incontextletters.html
 type letterShape struct {
@@ -97,7 +89,7 @@ var arabicAlphabetShapes = map[rune]letterShape{
 	// Letter (ﻱ)
 	'\u064A': {Independent: '\uFEF1', Initial: '\uFEF3', Medial: '\uFEF4', Final: '\uFEF2'},
 	// Letter (ﻯ)
-	'\u0649': {Independent: '\uFEEF', Initial: '\u0649', Medial: '\uFEF0', Final: '\uFEF0'},
+	'\u0649': {Independent: '\uFEEF', Initial: '\uFBE8', Medial: '\uFBE9', Final: '\uFEF0'},
 	// Letter (ـ)
 	'\u0640': {Independent: '\u0640', Initial: '\u0640', Medial: '\u0640', Final: '\u0640'},
 	// Letter (ﻻ)
@@ -108,6 +100,89 @@ var arabicAlphabetShapes = map[rune]letterShape{
 	'\uFEF9': {Independent: '\uFEF9', Initial: '\uFEF9', Medial: '\uFEFA', Final: '\uFEFA'},
 	// Letter (ﻵ)
 	'\uFEF5': {Independent: '\uFEF5', Initial: '\uFEF5', Medial: '\uFEF6', Final: '\uFEF6'},
+	/*
+		Persian, Urdu and Kurdish letters (Arabic Presentation Forms-A)
+	*/
+	// Letter (ﭐ)
+	'\u0671': {Independent: '\uFB50', Initial: '\u0671', Medial: '\uFB51', Final: '\uFB51'},
+	// Letter (ﭒ)
+	'\u067B': {Independent: '\uFB52', Initial: '\uFB54', Medial: '\uFB55', Final: '\uFB53'},
+	// Letter (ﭖ)
+	'\u067E': {Independent: '\uFB56', Initial: '\uFB58', Medial: '\uFB59', Final: '\uFB57'},
+	// Letter (ﭚ)
+	'\u0680': {Independent: '\uFB5A', Initial: '\uFB5C', Medial: '\uFB5D', Final: '\uFB5B'},
+	// Letter (ﭞ)
+	'\u067A': {Independent: '\uFB5E', Initial: '\uFB60', Medial: '\uFB61', Final: '\uFB5F'},
+	// Letter (ﭢ)
+	'\u067F': {Independent: '\uFB62', Initial: '\uFB64', Medial: '\uFB65', Final: '\uFB63'},
+	// Letter (ﭦ)
+	'\u0679': {Independent: '\uFB66', Initial: '\uFB68', Medial: '\uFB69', Final: '\uFB67'},
+	// Letter (ﭪ)
+	'\u06A4': {Independent: '\uFB6A', Initial: '\uFB6C', Medial: '\uFB6D', Final: '\uFB6B'},
+	// Letter (ﭮ)
+	'\u06A6': {Independent: '\uFB6E', Initial: '\uFB70', Medial: '\uFB71', Final: '\uFB6F'},
+	// Letter (ﭲ)
+	'\u0684': {Independent: '\uFB72', Initial: '\uFB74', Medial: '\uFB75', Final: '\uFB73'},
+	// Letter (ﭶ)
+	'\u0683': {Independent: '\uFB76', Initial: '\uFB78', Medial: '\uFB79', Final: '\uFB77'},
+	// Letter (ﭺ)
+	'\u0686': {Independent: '\uFB7A', Initial: '\uFB7C', Medial: '\uFB7D', Final: '\uFB7B'},
+	// Letter (ﭾ)
+	'\u0687': {Independent: '\uFB7E', Initial: '\uFB80', Medial: '\uFB81', Final: '\uFB7F'},
+	// Letter (ﮂ)
+	'\u068D': {Independent: '\uFB82', Initial: '\u068D', Medial: '\uFB83', Final: '\uFB83'},
+	// Letter (ﮄ)
+	'\u068C': {Independent: '\uFB84', Initial: '\u068C', Medial: '\uFB85', Final: '\uFB85'},
+	// Letter (ﮆ)
+	'\u068E': {Independent: '\uFB86', Initial: '\u068E', Medial: '\uFB87', Final: '\uFB87'},
+	// Letter (ﮈ)
+	'\u0688': {Independent: '\uFB88', Initial: '\u0688', Medial: '\uFB89', Final: '\uFB89'},
+	// Letter (ﮊ)
+	'\u0698': {Independent: '\uFB8A', Initial: '\u0698', Medial: '\uFB8B', Final: '\uFB8B'},
+	// Letter (ﮌ)
+	'\u0691': {Independent: '\uFB8C', Initial: '\u0691', Medial: '\uFB8D', Final: '\uFB8D'},
+	// Letter (ﮎ)
+	'\u06A9': {Independent: '\uFB8E', Initial: '\uFB90', Medial: '\uFB91', Final: '\uFB8F'},
+	// Letter (ﮒ)
+	'\u06AF': {Independent: '\uFB92', Initial: '\uFB94', Medial: '\uFB95', Final: '\uFB93'},
+	// Letter (ﮖ)
+	'\u06B3': {Independent: '\uFB96', Initial: '\uFB98', Medial: '\uFB99', Final: '\uFB97'},
+	// Letter (ﮚ)
+	'\u06B1': {Independent: '\uFB9A', Initial: '\uFB9C', Medial: '\uFB9D', Final: '\uFB9B'},
+	// Letter (ﮠ)
+	'\u06BB': {Independent: '\uFBA0', Initial: '\uFBA2', Medial: '\uFBA3', Final: '\uFBA1'},
+	// Letter (ﮤ)
+	'\u06C0': {Independent: '\uFBA4', Initial: '\u06C0', Medial: '\uFBA5', Final: '\uFBA5'},
+	// Letter (ﮦ)
+	'\u06C1': {Independent: '\uFBA6', Initial: '\uFBA8', Medial: '\uFBA9', Final: '\uFBA7'},
+	// Letter (ﮪ)
+	'\u06BE': {Independent: '\uFBAA', Initial: '\uFBAC', Medial: '\uFBAD', Final: '\uFBAB'},
+	// Letter (ﮮ)
+	'\u06D2': {Independent: '\uFBAE', Initial: '\u06D2', Medial: '\uFBAF', Final: '\uFBAF'},
+	// Letter (ﮰ)
+	'\u06D3': {Independent: '\uFBB0', Initial: '\u06D3', Medial: '\uFBB1', Final: '\uFBB1'},
+	// Letter (ﯓ)
+	'\u06AD': {Independent: '\uFBD3', Initial: '\uFBD5', Medial: '\uFBD6', Final: '\uFBD4'},
+	// Letter (ﯗ)
+	'\u06C7': {Independent: '\uFBD7', Initial: '\u06C7', Medial: '\uFBD8', Final: '\uFBD8'},
+	// Letter (ﯙ)
+	'\u06C6': {Independent: '\uFBD9', Initial: '\u06C6', Medial: '\uFBDA', Final: '\uFBDA'},
+	// Letter (ﯛ)
+	'\u06C8': {Independent: '\uFBDB', Initial: '\u06C8', Medial: '\uFBDC', Final: '\uFBDC'},
+	// Letter (ﯞ)
+	'\u06CB': {Independent: '\uFBDE', Initial: '\u06CB', Medial: '\uFBDF', Final: '\uFBDF'},
+	// Letter (ﯠ)
+	'\u06C5': {Independent: '\uFBE0', Initial: '\u06C5', Medial: '\uFBE1', Final: '\uFBE1'},
+	// Letter (ﯢ)
+	'\u06C9': {Independent: '\uFBE2', Initial: '\u06C9', Medial: '\uFBE3', Final: '\uFBE3'},
+	// Letter (ﯤ)
+	'\u06D0': {Independent: '\uFBE4', Initial: '\uFBE6', Medial: '\uFBE7', Final: '\uFBE5'},
+	// Letter (ﯼ)
+	'\u06CC': {Independent: '\uFBFC', Initial: '\uFBFE', Medial: '\uFBFF', Final: '\uFBFD'},
+	// Letter (ﮞ) has no initial and medial presentation forms
+	'\u06BA': {Independent: '\uFB9E', Initial: '\u06BA', Medial: '\u06BA', Final: '\uFB9F'},
+	// Letter (ﯝ) has no final presentation form
+	'\u0677': {Independent: '\uFBDD', Initial: '\u0677', Medial: '\u0677', Final: '\u0677'},
 }
 
 // Normalizable Arabic letters
@@ -161,71 +236,46 @@ const (
 	AlefWaslah = '\u0671'
 )
 
-//Number groups in Arabic
-var _zeroToNine = []string{
-	"صفر", "واحد", "اثنان", "ثلاثة", "أربعة",
-	"خمسة", "ستة", "سبعة", "ثمانية", "تسعة",
+//RemoveHarakat will remove harakat from arabic text
+func RemoveHarakat(input string) string {
+	return harakatRemover.Normalize(input)
 }
 
-var _elevenToNineteen = []string{
-	"عشرة", "أحد عشر", "اثنا عشر", "ثلاثة عشر", "أربعة عشر",
-	"خمسة عشر", "ستة عشر", "سبعة عشر", "ثمانية عشر", "تسعة عشر",
+//Normalize will prepare an arabic text to search and index
+func Normalize(input string) string {
+	return defaultNormalizer.Normalize(input)
 }
 
-var _tens = []string{
-	"", "", "عشرون", "ثلاثون", "أربعون", "خمسون",
-	"ستون", "سبعون", "ثمانون", "تسعون",
-}
-var _hundreds = []string{
-	"", "مئة", "مئتان", "ثلاثمئة", "أربعمئة", "خمسمئة", "ستمئة", "سبعمئة", "ثمانمئة", "تسعمئة",
-}
-var _scaleNumbers = []string{
-	"", "ألف", "مليون", "مليار",
+//RemoveHarakatAligned removes harakat like RemoveHarakat and returns the alignment
+//of the result with the input, to project positions found in the result onto the input
+func RemoveHarakatAligned(input string) (string, Alignment) {
+	return harakatRemover.NormalizeAligned(input)
 }
 
-//RemoveHarakat will remove harakat from arabic text
-func RemoveHarakat(input string) string {
-	input = normalizeTransform(input)
-	runes := bytes.Runes([]byte(input))
-	for i := 0; i < len(runes); i++ {
-		//fmt.Println(string(runes[i]))
-		switch runes[i] {
-		//Remove Waslah from AlefWaslah / Waslah is considered part of harakat/تَشْكِيل ?
-		case AlefWaslah:
-			runes[i] = Alef
-		}
-	}
-	return string(runes)
+//NormalizeAligned normalizes the text like Normalize and returns the alignment
+//of the result with the input, to project positions found in the result onto the input
+func NormalizeAligned(input string) (string, Alignment) {
+	return defaultNormalizer.NormalizeAligned(input)
 }
 
-//Normalize will prepare an arabic text to search and index
-func Normalize(input string) string {
-	input = normalizeTransform(input)
-	runes := bytes.Runes([]byte(input))
-	for i := 0; i < len(runes); i++ {
-		//fmt.Println(string(runes[i]))
-		switch runes[i] {
-		//Normalizable letters
-		case AlefMad, AlefHamzaAbove, AlefHamzaBelow, AlefWaslah:
-			runes[i] = Alef
-		case DotlessYae:
-			runes[i] = Yae
-		case TehMarbuta:
-			runes[i] = Hae
-		}
-	}
-	//@TODO: optimize runes by converting it to bytes, arabic letters use only 2 bytes
-	return string(runes)
+//RemoveHarakatTransformer returns a transformer that removes harakat like RemoveHarakat,
+//it can be wrapped around readers and writers to process large texts in a streaming fashion
+func RemoveHarakatTransformer() transform.SpanningTransformer {
+	return harakatRemover
 }
 
-// Use text/transform algorithm for faster normalization
-func normalizeTransform(input string) string {
-	//Use text/transform algorithm for faster normalization
-	tm := transform.Chain(runes.Remove(runes.In(normalizable)))
-	input, _, _ = transform.String(tm, input)
-	return input
+//NormalizeTransformer returns a transformer that normalizes text like Normalize,
+//it can be wrapped around readers and writers to process large texts in a streaming fashion
+func NormalizeTransformer() transform.SpanningTransformer {
+	return defaultNormalizer
 }
 
+//Normalizers backing RemoveHarakat and Normalize
+var (
+	harakatRemover    = NewNormalizer(RemoveHarakatOptions)
+	defaultNormalizer = NewNormalizer(NormalizeDefaultOptions)
+)
+
 //deleteRune will delete a rune from the slice while keeping the order of runes
 func deleteRune(runes []rune, i int) []rune {
 	if i >= len(runes) {
@@ -235,87 +285,6 @@ func deleteRune(runes []rune, i int) []rune {
 	return runes
 }
 
-// SpellNumber will transform a number into a readable arabic version
-func SpellNumber(input int) string {
-
-	var stringOfNum []string
-
-	if input < 0 {
-		stringOfNum = append(stringOfNum, "سالب")
-		input *= -1
-	}
-
-	if input < 10 {
-		stringOfNum = append(stringOfNum, _zeroToNine[input])
-		return strings.TrimSpace(strings.Join(stringOfNum, " "))
-	}
-
-	groups := []int{}
-
-	for input > 0 {
-		groups = append(groups, input%1000)
-		input = input / 1000
-	}
-
-	for i := len(groups) - 1; i >= 0; i-- {
-		//Get each group with its decimal position
-		group := groups[i]
-		if group == 0 {
-			continue
-		}
-
-		// [0 0 x]
-		hundreds := group / 100 % 10
-		// [0 x 0]
-		tens := group / 10 % 10
-		// [x 0 0]
-		zeros := group % 10
-
-		if hundreds > 0 {
-			if i == len(groups)-1 {
-				stringOfNum = append(stringOfNum, _hundreds[hundreds])
-			} else {
-				stringOfNum = append(stringOfNum, "و", _hundreds[hundreds])
-			}
-		}
-
-		//Move to scale number
-		if tens == 0 && zeros == 0 {
-			goto scale
-		}
-
-		switch tens {
-		case 0:
-			if zeros > 1 {
-				stringOfNum = append(stringOfNum, _zeroToNine[zeros])
-			}
-		case 1:
-			stringOfNum = append(stringOfNum, _elevenToNineteen[zeros])
-			break
-		default:
-			if zeros > 0 {
-				word := fmt.Sprintf("و %s و %s", _zeroToNine[zeros], _tens[tens])
-				stringOfNum = append(stringOfNum, word)
-			} else {
-				if len(stringOfNum) > 1 {
-					stringOfNum = append(stringOfNum, "و", _tens[tens])
-				} else {
-					stringOfNum = append(stringOfNum, _tens[tens])
-				}
-			}
-			break
-		}
-
-		// Scale position
-	scale:
-		if mega := _scaleNumbers[i]; mega != "" {
-			stringOfNum = append(stringOfNum, mega)
-		}
-	}
-
-	return strings.TrimSpace(strings.Join(stringOfNum, " "))
-}
-
 // Tashkeel will add matching diacritics to arabic text
 func Tashkeel(input string) string {
 	JarrWords := []string{"من", "الي", "عن", "على", "مذ", "خلا", "عدا", "حاشا"}
@@ -340,155 +309,144 @@ func contains(s []string, str string) bool {
 	return false
 }
 
-//Shape will reconstruct arabic text to be connected correctly
+//Shape will reconstruct arabic text to be connected correctly, the shaped text is laid out
+//right to left in visual order using the Unicode Bidirectional Algorithm
 func Shape(input string) string {
-	var langSections []string
-	var continousLangAr string
-	var continousLangLt string
-
-	for _, letter := range input {
-		if IsArabicLetter(letter) {
-			if len(continousLangLt) > 0 {
-				langSections = append(langSections, strings.TrimSpace(continousLangLt))
-			}
-			continousLangLt = ""
-			continousLangAr += string(letter)
-		} else {
-			if len(continousLangAr) > 0 {
-				langSections = append(langSections, strings.TrimSpace(continousLangAr))
-			}
-			continousLangAr = ""
-			continousLangLt += string(letter)
-		}
-	}
-	if len(continousLangLt) > 0 {
-		fmt.Println(continousLangLt)
-		langSections = append(langSections, strings.TrimSpace(continousLangLt))
-	}
-	if len(continousLangAr) > 0 {
-		fmt.Printf("\"%s\"\n", continousLangAr)
-		langSections = append(langSections, strings.TrimSpace(continousLangAr))
-	}
+	return ShapeWithDirection(input, RightToLeft)
+}
 
-	var shapedSentence []string
-	for _, section := range langSections {
-		if IsArabic(section) {
-			for _, word := range strings.Fields(section) {
-				shapedSentence = append(shapedSentence, shapeWord(word))
-			}
-		} else {
-			shapedSentence = append(shapedSentence, section)
-		}
-	}
-	//Reverse words
-	for i := len(shapedSentence)/2 - 1; i >= 0; i-- {
-		opp := len(shapedSentence) - 1 - i
-		shapedSentence[i], shapedSentence[opp] = shapedSentence[opp], shapedSentence[i]
-	}
-	return strings.Join(shapedSentence, " ")
+//ShapeWithDirection will reconstruct arabic text to be connected correctly and reorder it visually,
+//lines are laid out in the given base direction
+func ShapeWithDirection(input string, dir Direction) string {
+	return reorder(shapeText(input), dir)
 }
 
-//shapeWord will reconstruct an arabic word to be connected correctly
-func shapeWord(input string) string {
-	if !IsArabic(input) {
-		return input
+//shapeText will connect the arabic words of a text in logical order
+func shapeText(input string) string {
+	var shaped strings.Builder
+	for _, letter := range shapeLetters(input) {
+		shaped.WriteRune(letter.shape)
 	}
+	return shaped.String()
+}
 
-	var shapedInput bytes.Buffer
+//shapedLetter is a rune of shaped text in logical order
+type shapedLetter struct {
+	shape rune
+	//start and end are the byte offsets of the letters of the input it was shaped from,
+	//a lam alef ligature covers both letters and the harakat between them
+	start, end int
+	//split is the offset of the alef of a lam alef ligature, 0 for other letters
+	split int
+}
 
-	//Convert input into runes
-	inputRunes := []rune(RemoveHarakat(input))
-	for i := range inputRunes {
-		//Get Bounding back and front letters
-		var backLetter, frontLetter rune
-		if i-1 >= 0 {
-			backLetter = inputRunes[i-1]
-		}
-		if i != len(inputRunes)-1 {
-			frontLetter = inputRunes[i+1]
+//shapeLetters will connect the arabic words of a text in logical order and keep track of the input letters
+func shapeLetters(input string) []shapedLetter {
+	shaped := make([]shapedLetter, 0, len(input))
+	var word []rune
+	var offsets []int
+	for i, letter := range input {
+		if IsArabicLetter(letter) || isJoinControl(letter) {
+			word = append(word, letter)
+			offsets = append(offsets, i)
+			continue
 		}
-		//Fix the letter based on bounding letters
-		if _, ok := arabicAlphabetShapes[inputRunes[i]]; ok {
-			adjustedLetter := adjustLetter(letterGroup{backLetter, inputRunes[i], frontLetter})
-			shapedInput.WriteRune(adjustedLetter)
-		} else {
-			shapedInput.WriteRune(inputRunes[i])
+		if len(word) > 0 {
+			shaped = appendWord(shaped, word, offsets, i)
+			word, offsets = word[:0], offsets[:0]
 		}
+		shaped = append(shaped, shapedLetter{shape: letter, start: i, end: i + utf8.RuneLen(letter)})
 	}
+	return appendWord(shaped, word, offsets, len(input))
+}
 
-	//In case no Tashkeel deteted, same size of runes
-	if len([]rune(shapedInput.String())) == len([]rune(input)) {
-		return reverse(shapedInput.String())
-	}
-
-	var shapedInputTashkeel bytes.Buffer
-	inputTashkeelRunes := []rune(input)
-
-	letterIndex := 0
-	//Restore Tashkeel
-	for i := range inputTashkeelRunes {
-		if _, ok := arabicAlphabetShapes[inputTashkeelRunes[i]]; ok {
-			shapedInputTashkeel.WriteRune([]rune(shapedInput.String())[letterIndex])
-			letterIndex++
-		} else {
-			shapedInputTashkeel.WriteRune(inputTashkeelRunes[i])
+//appendWord shapes a word whose letters start at offsets and ends at end
+func appendWord(shaped []shapedLetter, word []rune, offsets []int, end int) []shapedLetter {
+	offsets = append(offsets, end)
+	for _, letter := range shapeWord(word) {
+		if letter.split > 0 {
+			letter.split = offsets[letter.split]
 		}
+		letter.start, letter.end = offsets[letter.start], offsets[letter.end]
+		shaped = append(shaped, letter)
 	}
-
-	return reverse(shapedInputTashkeel.String())
-
+	return shaped
 }
 
-//reverse the arabic string for RTL support in rendering
-func reverse(s string) string {
-	runes := []rune(s)
-	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
-		runes[i], runes[j] = runes[j], runes[i]
-	}
-	return string(runes)
+//lamAlefLigatures maps the alef variants following a lam to the ligature replacing both letters
+var lamAlefLigatures = map[rune]rune{
+	Alef:           '\uFEFB',
+	AlefHamzaAbove: '\uFEF7',
+	AlefHamzaBelow: '\uFEF9',
+	AlefMad:        '\uFEF5',
 }
 
-//adjustLetter will adjust the arabic letter depending on its position
-func adjustLetter(g letterGroup) rune {
-
-	switch {
-	//Inbetween 2 letters
-	case g.backLetter > 0 && g.frontLetter > 0:
-		if isAlwaysInitial(g.backLetter) {
-			return arabicAlphabetShapes[g.letter].Initial
-		}
-		return arabicAlphabetShapes[g.letter].Medial
+//Lam => ل
+const Lam = '\u0644'
 
-	//Not preceded by any letter
-	case g.backLetter == 0 && g.frontLetter > 0:
-		return arabicAlphabetShapes[g.letter].Initial
+//shapeWord will reconstruct an arabic word to be connected correctly, the word is kept in logical order.
+//Letters are connected by their joining types, harakat and other transparent marks are skipped.
+//Offsets of the shaped letters are indices of the word runes
+func shapeWord(letters []rune) []shapedLetter {
+	types := make([]joiningType, len(letters))
+	for i, letter := range letters {
+		types[i] = joiningTypeOf(letter)
+	}
 
-	//Not followed by any letter
-	case g.backLetter > 0 && g.frontLetter == 0:
-		if isAlwaysInitial(g.backLetter) {
-			return arabicAlphabetShapes[g.letter].Independent
+	shaped := make([]shapedLetter, 0, len(letters))
+	//Alef letters drawn as part of lam alef ligatures
+	ligated := make([]bool, len(letters))
+	for i, letter := range letters {
+		if ligated[i] {
+			continue
 		}
-		return arabicAlphabetShapes[g.letter].Final
-
-	default:
-		return arabicAlphabetShapes[g.letter].Independent
+		previous, next := adjacentLetter(types, i, -1), adjacentLetter(types, i, 1)
+		joinsPrevious := previous >= 0 && types[previous].joinsFollowing() && types[i].joinsPreceding()
+		joinsNext := next >= 0 && types[next].joinsPreceding() && types[i].joinsFollowing()
+
+		//Lam followed by alef is replaced by a ligature, which doesn't connect to the next letter
+		if letter == Lam && next >= 0 {
+			if ligature, ok := lamAlefLigatures[letters[next]]; ok {
+				ligated[next] = true
+				shaped = append(shaped, shapedLetter{shape: letterForm(ligature, joinsPrevious, false), start: i, end: next + 1, split: next})
+				continue
+			}
+		}
+		shaped = append(shaped, shapedLetter{shape: letterForm(letter, joinsPrevious, joinsNext), start: i, end: i + 1})
 	}
+	return shaped
 }
 
-//Check if the letter is always .Initial
-func isAlwaysInitial(letter rune) bool {
-	alwaysInitial := [13]rune{'\u0627', '\u0623', '\u0622', '\u0625', '\u0649', '\u0621', '\u0624', '\u0629', '\u062f', '\u0630', '\u0631', '\u0632', '\u0648'}
-	for _, item := range alwaysInitial {
-		if item == letter {
-			return true
+//adjacentLetter returns the index of the closest non transparent letter in the direction step, or -1
+func adjacentLetter(types []joiningType, i, step int) int {
+	for i += step; i >= 0 && i < len(types); i += step {
+		if types[i] != transparent {
+			return i
 		}
 	}
-	return false
+	return -1
+}
+
+//letterForm returns the shape of the letter depending on its connections
+func letterForm(letter rune, joinsPrevious, joinsNext bool) rune {
+	shapes, ok := arabicAlphabetShapes[letter]
+	switch {
+	case !ok:
+		return letter
+	case joinsPrevious && joinsNext:
+		return shapes.Medial
+	case joinsPrevious:
+		return shapes.Final
+	case joinsNext:
+		return shapes.Initial
+	default:
+		return shapes.Independent
+	}
 }
 
-//IsArabicLetter checks if the letter is arabic
+//IsArabicLetter checks if the letter is arabic, letters of the Arabic Supplement block are included
 func IsArabicLetter(ch rune) bool {
-	return (ch >= 0x600 && ch <= 0x6FF)
+	return (ch >= 0x600 && ch <= 0x6FF) || (ch >= 0x750 && ch <= 0x77F)
 }
 
 //IsArabic checks if the input string contains arabic unicode only
@@ -1,10 +1,24 @@
 package garabic
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"math/big"
+	"math/bits"
+	"math/rand"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/bidi"
 )
 
 const succeed = "\u2705"
@@ -46,6 +60,91 @@ func TestNormalizeBigText(t *testing.T) {
 	t.Logf("\t%s\t Should normalize all text file\t", succeed)
 }
 
+//TestNormalizeTransformer ..
+func TestNormalizeTransformer(t *testing.T) {
+	originalArabicText, err := ioutil.ReadFile("test_data/bigText.txt")
+	if err != nil {
+		t.Errorf("\t%s\t Reading file failed with error:(%s)\t", failed, err)
+	}
+	preNormalizedArabicText, err := ioutil.ReadFile("test_data/normalizedBigText.txt")
+	if err != nil {
+		t.Errorf("\t%s\t Reading prenormalized file failed with error:(%s)\t", failed, err)
+	}
+	//Stream the file one byte at a time to split multibyte runes between reads
+	reader := transform.NewReader(iotest.OneByteReader(bytes.NewReader(originalArabicText)), NormalizeTransformer())
+	normalized, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Errorf("\t%s\t Reading normalized stream failed with error:(%s)\t", failed, err)
+	}
+	if !bytes.Equal(normalized, preNormalizedArabicText) {
+		t.Errorf("\t%s\t Normalized stream doesn't match [length of the normalized version: %d\t length of the original prenormalized version: %d\t", failed, len(normalized), len(preNormalizedArabicText))
+	}
+	t.Logf("\t%s\t Should normalize all text stream\t", succeed)
+}
+
+//TestRemoveHarakatTransformer ...
+func TestRemoveHarakatTransformer(t *testing.T) {
+	t.Log("Given an arabic string, the transformer should remove harakat like RemoveHarakat")
+	{
+		for i, tt := range removeHarakatTestCases {
+			normalized, _, err := transform.String(RemoveHarakatTransformer(), tt.input)
+			t.Logf("\tTest: %d\t Transforming %s", i, tt.input)
+			if err != nil || normalized != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be transformed to %s, got %s (%v) instead", failed, tt.description, tt.expected, normalized, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be transformed to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestNormalizerInvalidUTF8 ...
+func TestNormalizerInvalidUTF8(t *testing.T) {
+	t.Log("Given invalid utf8, the transformer and Normalize should replace it the same way")
+	{
+		for i, input := range []string{"a\xffأ", "\xd8سَنة", "مرحبا\xd8", "\xef\xbf\xbd\xff"} {
+			expected := Normalize(input)
+			transformed, _, err := transform.String(NormalizeTransformer(), input)
+			t.Logf("\tTest: %d\t Transforming %q", i, input)
+			if err != nil || transformed != expected || !utf8.ValidString(transformed) {
+				t.Errorf("\t%s\tShould be transformed to %q, got %q (%v) instead", failed, expected, transformed, err)
+			} else {
+				t.Logf("\t%s\tShould be transformed to %q", succeed, expected)
+			}
+		}
+	}
+}
+
+//TestNormalizerSpan ...
+func TestNormalizerSpan(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		atEOF       bool
+		expectedN   int
+		expectedErr error
+	}{
+		{"Already normalized text", "احمد", true, len("احمد"), nil},
+		{"Stops at the first foldable letter", "محمد أحمد", true, len("محمد "), transform.ErrEndOfSpan},
+		{"Stops at harakat", "سَنة", true, len("س"), transform.ErrEndOfSpan},
+		{"Waits for an incomplete rune", "سن\xd8", false, len("سن"), transform.ErrShortSrc},
+		{"Stops at invalid bytes", "سن\xff", true, len("سن"), transform.ErrEndOfSpan},
+	}
+
+	t.Log("Given a text, the normalizer should span the prefix that stays unchanged")
+	{
+		for i, tt := range testCases {
+			n, err := NormalizeTransformer().Span([]byte(tt.input), tt.atEOF)
+			t.Logf("\tTest: %d\t Spanning %q", i, tt.input)
+			if n != tt.expectedN || err != tt.expectedErr {
+				t.Errorf("\t%s\t(%s)\tShould span %d (%v), got %d (%v) instead", failed, tt.description, tt.expectedN, tt.expectedErr, n, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould span %d", succeed, tt.description, tt.expectedN)
+			}
+		}
+	}
+}
+
 //TestNormalize ...
 func TestNormalize(t *testing.T) {
 	t.Log("Given an arabic string it should be normalized")
@@ -62,6 +161,62 @@ func TestNormalize(t *testing.T) {
 	}
 }
 
+//TestNormalizer ...
+func TestNormalizer(t *testing.T) {
+	t.Log("Given an arabic string and normalization options it should be normalized")
+	{
+		for i, tt := range normalizerTestCases {
+			normalized := NewNormalizer(tt.options).Normalize(tt.input)
+			t.Logf("\tTest: %d\t Normalizing %s", i, tt.input)
+			if normalized != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be normalized to %s, got %s instead", failed, tt.description, tt.expected, normalized)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be normalized to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestNormalizeAligned ...
+func TestNormalizeAligned(t *testing.T) {
+	t.Log("Given a match in a normalized text, it should be projected onto the original text")
+	{
+		for i, tt := range alignmentTestCases {
+			normalized, alignment := NormalizeAligned(tt.input)
+			start := strings.Index(normalized, tt.query)
+			origStart, origEnd := alignment.Original(start, start+len(tt.query))
+			runeStart := utf8.RuneCountInString(normalized[:start])
+			origRuneStart, origRuneEnd := alignment.OriginalRunes(runeStart, runeStart+utf8.RuneCountInString(tt.query))
+			t.Logf("\tTest: %d\t Projecting %s in %s", i, tt.query, tt.input)
+			if projected := tt.input[origStart:origEnd]; projected != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be projected to %s, got %s instead", failed, tt.description, tt.expected, projected)
+			} else if projected := string([]rune(tt.input)[origRuneStart:origRuneEnd]); projected != tt.expected {
+				t.Errorf("\t%s\t(%s)\tRunes should be projected to %s, got %s instead", failed, tt.description, tt.expected, projected)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be projected to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestRemoveHarakatAligned ...
+func TestRemoveHarakatAligned(t *testing.T) {
+	t.Log("Given an arabic string, the aligned result should match RemoveHarakat")
+	{
+		for i, tt := range removeHarakatTestCases {
+			normalized, alignment := RemoveHarakatAligned(tt.input)
+			t.Logf("\tTest: %d\t Normalizing %s", i, tt.input)
+			if normalized != tt.expected || len(alignment) != utf8.RuneCountInString(normalized) {
+				t.Errorf("\t%s\t(%s)\tShould be normalized to %s with %d segments, got %s with %d segments instead", failed, tt.description, tt.expected, utf8.RuneCountInString(tt.expected), normalized, len(alignment))
+			} else if start, end := alignment.Original(0, len(normalized)); tt.input[start:end] != tt.input {
+				t.Errorf("\t%s\t(%s)\tWhole text should be projected to %s, got %s instead", failed, tt.description, tt.input, tt.input[start:end])
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be normalized to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
 //TestDeleteRune ...
 func TestDeleteRune(t *testing.T) {
 
@@ -131,6 +286,305 @@ func TestSpellNumber(t *testing.T) {
 	}
 }
 
+//TestSpellNumberWithOptions ...
+func TestSpellNumberWithOptions(t *testing.T) {
+	t.Log("Given a number counting a feminine noun it should be return readable string of it in arabic")
+	{
+		for i, tt := range spellFeminineNumberTestCases {
+			textOfNum := SpellNumberWithOptions(tt.input, SpellOptions{Feminine: true})
+			t.Logf("\tTest: %d\t Spelling Number %d", i, tt.input)
+			if textOfNum != tt.expected {
+				t.Errorf("\t%s\t\tShould be converted to %s, got %s instead", failed, tt.expected, textOfNum)
+			} else {
+				t.Logf("\t%s\t\tShould be converted to %s", succeed, tt.expected)
+			}
+		}
+	}
+}
+
+//TestSpellNumberCase ...
+func TestSpellNumberCase(t *testing.T) {
+	t.Log("Given a number and a grammatical case it should be return readable string of it in arabic")
+	{
+		for i, tt := range spellNumberCaseTestCases {
+			textOfNum := SpellNumberWithOptions(tt.input, tt.opts)
+			t.Logf("\tTest: %d\t Spelling Number %d", i, tt.input)
+			if textOfNum != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s, got %s instead", failed, tt.description, tt.expected, textOfNum)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestSpellOrdinal ...
+func TestSpellOrdinal(t *testing.T) {
+	t.Log("Given a number it should be return readable string of its ordinal in arabic")
+	{
+		for i, tt := range spellOrdinalTestCases {
+			ordinal, err := SpellOrdinal(tt.input, tt.opts)
+			t.Logf("\tTest: %d\t Spelling Ordinal %d", i, tt.input)
+			if ordinal != tt.expected || err != nil {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s, got %s (%v) instead", failed, tt.description, tt.expected, ordinal, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+
+	t.Log("Given zero it should fail")
+	{
+		if _, err := SpellOrdinal(0, OrdinalOptions{}); err != ErrNotOrdinal {
+			t.Errorf("\t%s\tShould fail with %v, got %v instead", failed, ErrNotOrdinal, err)
+		} else {
+			t.Logf("\t%s\tShould fail with %v", succeed, ErrNotOrdinal)
+		}
+	}
+}
+
+//TestSpellQuantity ...
+func TestSpellQuantity(t *testing.T) {
+	t.Log("Given a number and a noun it should be return the readable quantity in arabic")
+	{
+		for i, tt := range spellQuantityTestCases {
+			quantity := SpellQuantityInCase(tt.input, tt.noun, tt.c)
+			t.Logf("\tTest: %d\t Spelling %d %s", i, tt.input, tt.noun.Singular)
+			if quantity != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s, got %s instead", failed, tt.description, tt.expected, quantity)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestSpellLargeNumbers ...
+func TestSpellLargeNumbers(t *testing.T) {
+	t.Log("Given a large number it should be return readable string of it in arabic")
+	{
+		for i, tt := range spellLargeNumberTestCases {
+			input, _ := new(big.Int).SetString(tt.input, 10)
+			textOfNum, err := SpellBigInt(input)
+			t.Logf("\tTest: %d\t Spelling Number %s", i, tt.input)
+			if textOfNum != tt.expected || err != tt.err {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s (%v), got %s (%v) instead", failed, tt.description, tt.expected, tt.err, textOfNum, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+			if input.IsInt64() && SpellInt64(input.Int64()) != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s as int64, got %s instead", failed, tt.description, tt.expected, SpellInt64(input.Int64()))
+			}
+			if input.IsUint64() && SpellUint64(input.Uint64()) != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s as uint64, got %s instead", failed, tt.description, tt.expected, SpellUint64(input.Uint64()))
+			}
+		}
+	}
+
+	t.Log("Given a nil big integer it should return an invalid number error")
+	{
+		if _, err := SpellBigInt(nil); err != ErrInvalidNumber {
+			t.Errorf("\t%s\tShould return %v, got %v instead", failed, ErrInvalidNumber, err)
+		} else {
+			t.Logf("\t%s\tShould return %v", succeed, ErrInvalidNumber)
+		}
+	}
+}
+
+//TestSpellAmount ...
+func TestSpellAmount(t *testing.T) {
+	t.Log("Given an amount of money it should be return the legal phrasing of it in arabic")
+	{
+		for i, tt := range spellAmountTestCases {
+			amount, err := SpellAmountString(tt.input, tt.currency)
+			t.Logf("\tTest: %d\t Spelling Amount %s", i, tt.input)
+			if amount != tt.expected || err != tt.err {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s (%v), got %s (%v) instead", failed, tt.description, tt.expected, tt.err, amount, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+
+	t.Log("Given an amount in subunits it should be spelled like its decimal number")
+	{
+		expected := "فقط سالب ثلاثة دنانير كويتية و خمسمئة فلس لا غير"
+		if amount := SpellAmount(-3500, KWD); amount != expected {
+			t.Errorf("\t%s\tShould be converted to %s, got %s instead", failed, expected, amount)
+		} else {
+			t.Logf("\t%s\tShould be converted to %s", succeed, expected)
+		}
+	}
+}
+
+//TestSpellDecimal ...
+func TestSpellDecimal(t *testing.T) {
+	t.Log("Given a decimal number it should be return readable string of it in arabic")
+	{
+		for i, tt := range spellDecimalTestCases {
+			textOfNum, err := SpellDecimal(tt.input, tt.opts)
+			t.Logf("\tTest: %d\t Spelling Decimal %s", i, tt.input)
+			if textOfNum != tt.expected || err != tt.err {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s (%v), got %s (%v) instead", failed, tt.description, tt.expected, tt.err, textOfNum, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+
+	t.Log("Given a float or a percentage it should be spelled like its decimal number")
+	{
+		tests := []struct {
+			spell    func() (string, error)
+			expected string
+		}{
+			{func() (string, error) { return SpellFloat(0.1, DecimalOptions{}) }, "صفر فاصلة واحد"},
+			{func() (string, error) { return SpellFloat(2.0/3, DecimalOptions{Precision: 3}) }, "صفر فاصلة ستمئة و سبعة و ستون"},
+			{func() (string, error) { return SpellPercent("25", DecimalOptions{}) }, "خمسة و عشرون بالمئة"},
+			{func() (string, error) { return SpellPercent("12.5", DecimalOptions{}) }, "اثنا عشر فاصلة خمسة بالمئة"},
+		}
+		for i, tt := range tests {
+			textOfNum, err := tt.spell()
+			if textOfNum != tt.expected || err != nil {
+				t.Errorf("\t%s\tTest: %d\tShould be converted to %s, got %s (%v) instead", failed, i, tt.expected, textOfNum, err)
+			} else {
+				t.Logf("\t%s\tTest: %d\tShould be converted to %s", succeed, i, tt.expected)
+			}
+		}
+		if _, err := SpellFloat(math.NaN(), DecimalOptions{}); err != ErrInvalidNumber {
+			t.Errorf("\t%s\tNaN should fail with %v, got %v instead", failed, ErrInvalidNumber, err)
+		}
+	}
+}
+
+//TestSpellFraction ...
+func TestSpellFraction(t *testing.T) {
+	t.Log("Given a fraction it should be return readable string of it in arabic")
+	{
+		for i, tt := range spellFractionTestCases {
+			textOfNum, err := SpellFraction(tt.numerator, tt.denominator)
+			t.Logf("\tTest: %d\t Spelling Fraction %d/%d", i, tt.numerator, tt.denominator)
+			if textOfNum != tt.expected || err != tt.err {
+				t.Errorf("\t%s\t\tShould be converted to %s (%v), got %s (%v) instead", failed, tt.expected, tt.err, textOfNum, err)
+			} else {
+				t.Logf("\t%s\t\tShould be converted to %s", succeed, tt.expected)
+			}
+		}
+		//The magnitude of the smallest int doesn't fit in an int
+		minInt, magnitude := -1<<(bits.UintSize-1), uint64(1)<<(bits.UintSize-1)
+		expected := "سالب " + SpellUint64(magnitude)
+		if textOfNum, err := SpellFraction(minInt, 1); textOfNum != expected || err != nil {
+			t.Errorf("\t%s\tThe smallest int should be converted to %s, got %s (%v) instead", failed, expected, textOfNum, err)
+		}
+		expected = "سالب واحد من " + spellGroups(digitGroups(magnitude), false, SpellOptions{Case: Genitive}, false)
+		if textOfNum, err := SpellFraction(1, minInt); textOfNum != expected || err != nil {
+			t.Errorf("\t%s\tThe smallest int denominator should be converted to %s, got %s (%v) instead", failed, expected, textOfNum, err)
+		}
+	}
+}
+
+//TestParseNumber ...
+func TestParseNumber(t *testing.T) {
+	t.Log("Given arabic number words it should be read back into a number")
+	{
+		for i, tt := range parseNumberTestCases {
+			number, err := ParseNumber(tt.input)
+			t.Logf("\tTest: %d\t Parsing %s", i, tt.input)
+			if number != tt.expected || !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("\t%s\t(%s)\tShould be read as %d (%v), got %d (%v) instead", failed, tt.description, tt.expected, tt.err, number, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be read as %d", succeed, tt.description, tt.expected)
+			}
+		}
+		for _, number := range []int{1, 2, 10, 11, 12, 19, 21, 100, 101, 102, 200, 999, 1002, 2000, 11225, 1000000, 2002002} {
+			for _, opts := range []SpellOptions{{}, {Feminine: true}, {Case: Accusative}, {Feminine: true, Case: Genitive}} {
+				spelled := SpellNumberWithOptions(number, opts)
+				if parsed, err := ParseNumber(spelled); parsed != int64(number) || err != nil {
+					t.Errorf("\t%s\t%s should be read as %d, got %d (%v) instead", failed, spelled, number, parsed, err)
+				}
+			}
+		}
+	}
+}
+
+//TestExtractNumbers ...
+func TestExtractNumbers(t *testing.T) {
+	t.Log("Given an arabic text the numbers written in it should be found")
+	{
+		for i, tt := range extractNumbersTestCases {
+			spans := ExtractNumbers(tt.input)
+			t.Logf("\tTest: %d\t Extracting numbers from %s", i, tt.input)
+			if len(spans) != len(tt.expected) {
+				t.Errorf("\t%s\t(%s)\tShould find %d numbers, got %d instead", failed, tt.description, len(tt.expected), len(spans))
+				continue
+			}
+			for j, span := range spans {
+				expected := tt.expected[j]
+				expected.Value, _ = new(big.Rat).SetString(tt.values[j])
+				if span.Kind != expected.Kind || span.Start != expected.Start || span.End != expected.End || span.Text != expected.Text || span.Value.Cmp(expected.Value) != 0 {
+					t.Errorf("\t%s\t(%s)\tShould find %+v, got %+v instead", failed, tt.description, expected, span)
+				} else {
+					t.Logf("\t%s\t(%s)\tShould find %s", succeed, tt.description, expected.Text)
+				}
+			}
+		}
+	}
+}
+
+//TestFormatNumber ...
+func TestFormatNumber(t *testing.T) {
+	t.Log("Given a number it should be written with the digits and separators of the locale")
+	{
+		for i, tt := range formatNumberTestCases {
+			formatted := FormatFloat(tt.input, tt.precision, tt.locale)
+			t.Logf("\tTest: %d\t Formatting %v", i, tt.input)
+			if formatted != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be written as %s, got %s instead", failed, tt.description, tt.expected, formatted)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be written as %s", succeed, tt.description, tt.expected)
+			}
+		}
+		if formatted := FormatInt(math.MinInt64, LocaleMaghreb); formatted != "-9.223.372.036.854.775.808" {
+			t.Errorf("\t%s\tThe smallest int64 should be grouped, got %s instead", failed, formatted)
+		}
+		if formatted := FormatFloat(math.Inf(1), -1, LocaleGulf); formatted != "+Inf" {
+			t.Errorf("\t%s\tInfinities should be kept, got %s instead", failed, formatted)
+		}
+		if formatted := FormatPercent(12.5, -1, LocaleGulf); formatted != "١٢٫٥٪" {
+			t.Errorf("\t%s\tPercentages should end with ٪, got %s instead", failed, formatted)
+		}
+	}
+}
+
+//TestParseFormatted ...
+func TestParseFormatted(t *testing.T) {
+	t.Log("Given a number written in an arabic locale it should be read back")
+	{
+		for i, tt := range parseFormattedTestCases {
+			number, err := ParseFormattedFloat(tt.input, tt.locale)
+			t.Logf("\tTest: %d\t Parsing %s", i, tt.input)
+			if number != tt.expected || err != tt.err {
+				t.Errorf("\t%s\t(%s)\tShould be read as %v (%v), got %v (%v) instead", failed, tt.description, tt.expected, tt.err, number, err)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be read as %v", succeed, tt.description, tt.expected)
+			}
+		}
+		for _, number := range []int64{0, 7, -1234, 1000000, math.MaxInt64, math.MinInt64} {
+			for _, locale := range []Locale{LocaleGulf, LocaleMaghreb} {
+				if parsed, err := ParseFormattedInt(FormatInt(number, locale), locale); parsed != number || err != nil {
+					t.Errorf("\t%s\t%d should be read back, got %d (%v) instead", failed, number, parsed, err)
+				}
+			}
+		}
+		if _, err := ParseFormattedInt("١٢٫٥", LocaleGulf); err != ErrInvalidNumber {
+			t.Errorf("\t%s\tDecimals should fail with %v, got %v instead", failed, ErrInvalidNumber, err)
+		}
+		if _, err := ParseFormattedInt("9,223,372,036,854,775,808", LocaleGulf); err != ErrNumberTooLarge {
+			t.Errorf("\t%s\tOverflows should fail with %v, got %v instead", failed, ErrNumberTooLarge, err)
+		}
+	}
+}
+
 //TestTashkeel ...
 func TestTashkeel(t *testing.T) {
 	t.Log("Given an arabic string, diacritics should be added correctly")
@@ -163,6 +617,339 @@ func TestShape(t *testing.T) {
 	}
 }
 
+//TestUnshape ...
+func TestUnshape(t *testing.T) {
+	t.Log("Given a shaped string in visual order, it should be converted to logical arabic text")
+	{
+		for i, tt := range unshapeTestCases {
+			unshaped := Unshape(tt.input)
+			t.Logf("\tTest: %d\t Unshaping: %s", i, tt.input)
+			if unshaped != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be converted to %s, got %s instead", failed, tt.description, tt.expected, unshaped)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be converted to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+	t.Log("Given a logical string, unshaping its shaped text should give it back")
+	{
+		for i, input := range []string{"لَا", "السَّلَامُ عَلَيْكُمْ", "لًّا إِلَهَ"} {
+			unshaped := Unshape(Shape(input))
+			t.Logf("\tTest: %d\t Shaping and unshaping: %s", i, input)
+			if unshaped != input {
+				t.Errorf("\t%s\tShould be converted back to %s, got %s instead", failed, input, unshaped)
+			} else {
+				t.Logf("\t%s\tShould be converted back to %s", succeed, input)
+			}
+		}
+	}
+}
+
+//TestJustify ...
+func TestJustify(t *testing.T) {
+	t.Log("Given an arabic line and a width, tatweel should be inserted to stretch the line to the width")
+	{
+		for i, tt := range justifyTestCases {
+			justified := Justify(tt.input, tt.width)
+			t.Logf("\tTest: %d\t Justifying: %s", i, tt.input)
+			if justified != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be justified to %s, got %s instead", failed, tt.description, tt.expected, justified)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be justified to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestJustifyWithOptions ...
+func TestJustifyWithOptions(t *testing.T) {
+	t.Log("Given justify options, the line should be measured and returned as requested")
+	{
+		//Tatweel is two units wide and other characters are three units wide
+		measure := func(s string) int {
+			width := 0
+			for _, r := range s {
+				if r == Tatweel {
+					width += 2
+				} else if !unicode.Is(unicode.Mn, r) {
+					width += 3
+				}
+			}
+			return width
+		}
+		tests := []struct {
+			description string
+			opts        JustifyOptions
+			expected    string
+		}{
+			{"Measuring with advances", JustifyOptions{Width: 39, Measure: measure}, "الســلام عليكـم"},
+			{"Measuring with advances that don't fill the width", JustifyOptions{Width: 40, Measure: measure}, "الســلام عليكـم"},
+			{"Returning shaped line", JustifyOptions{Width: 16, Shaped: true}, "ﻢــﻜﻴﻠﻋ ﻡﻼـــﺴﻟﺍ"},
+		}
+		for i, tt := range tests {
+			justified := JustifyWithOptions("السلام عليكم", tt.opts)
+			t.Logf("\tTest: %d\t %s", i, tt.description)
+			if justified != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be justified to %s, got %s instead", failed, tt.description, tt.expected, justified)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be justified to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestShapeParagraph ...
+func TestShapeParagraph(t *testing.T) {
+	t.Log("Given an arabic paragraph, it should be broken into lines then shaped and reordered line by line")
+	{
+		for i, tt := range shapeParagraphTestCases {
+			lines := ShapeParagraphWithDirection(tt.input, tt.maxWidth, nil, tt.dir)
+			t.Logf("\tTest: %d\t Breaking: %s", i, tt.input)
+			if !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("\t%s\t(%s)\tShould be broken into %q, got %q instead", failed, tt.description, tt.expected, lines)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be broken into %q", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+
+	t.Log("Given a measure function, lines should be broken by the measured width")
+	{
+		//Every character is two units wide
+		measure := func(s string) int { return 2 * countCharacters(s) }
+		expected := []string{"ﻡﻼﺴﻟﺍ", "ﻢﻜﻴﻠﻋ"}
+		lines := ShapeParagraph("السلام عليكم", 20, measure)
+		if !reflect.DeepEqual(lines, expected) {
+			t.Errorf("\t%s\tShould be broken into %q, got %q instead", failed, expected, lines)
+		} else {
+			t.Logf("\t%s\tShould be broken into %q", succeed, expected)
+		}
+	}
+}
+
+//TestShapeClusters ...
+func TestShapeClusters(t *testing.T) {
+	t.Log("Given an arabic string with harakat, the shaped glyphs should be returned with their marks in visual order")
+	{
+		for i, tt := range clusterTestCases {
+			clusters := ShapeClusters(tt.input)
+			t.Logf("\tTest: %d\t Clustering: %s", i, tt.input)
+			if !reflect.DeepEqual(clusters, tt.expected) {
+				t.Errorf("\t%s\t(%s)\tShould be clustered as %+v, got %+v instead", failed, tt.description, tt.expected, clusters)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be clustered as %+v", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+
+	t.Log("Given a paragraph, the clusters of each line should match the shaped lines")
+	{
+		for i, tt := range shapeParagraphTestCases {
+			lines := ShapeParagraphClusters(tt.input, tt.maxWidth, nil, tt.dir)
+			glyphs := make([]string, len(lines))
+			for l, line := range lines {
+				for _, cluster := range line {
+					glyphs[l] += string(cluster.Glyph)
+				}
+			}
+			expected := make([]string, len(tt.expected))
+			for l, line := range tt.expected {
+				expected[l] = strings.Map(func(r rune) rune {
+					if unicode.Is(unicode.Mn, r) {
+						return -1
+					}
+					return r
+				}, line)
+			}
+			t.Logf("\tTest: %d\t Clustering: %s", i, tt.input)
+			if !reflect.DeepEqual(glyphs, expected) {
+				t.Errorf("\t%s\t(%s)\tShould be clustered as %q, got %q instead", failed, tt.description, expected, glyphs)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be clustered as %q", succeed, tt.description, expected)
+			}
+		}
+	}
+}
+
+//TestShapeForTerminal ...
+func TestShapeForTerminal(t *testing.T) {
+	t.Log("Given a text, it should be shaped and reordered line by line for terminals")
+	{
+		for i, tt := range terminalTestCases {
+			shaped := ShapeForTerminalWithOptions(tt.input, tt.opts)
+			t.Logf("\tTest: %d\t Shaping: %q", i, tt.input)
+			if shaped != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be shaped to %q, got %q instead", failed, tt.description, tt.expected, shaped)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be shaped to %q", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+
+	t.Log("Given a table written with tabwriter, its columns should stay aligned")
+	{
+		var table bytes.Buffer
+		w := tabwriter.NewWriter(&table, 0, 0, 1, ' ', tabwriter.Debug)
+		fmt.Fprint(w, ShapeForTerminal("الاسم\tالمدينة\nمحمد\tالقاهرة\nعَلِيّ\tجُدَّة\n"))
+		w.Flush()
+		for _, line := range strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n") {
+			if column := DisplayWidth(line[:strings.Index(line, "|")]); column != 5 {
+				t.Errorf("\t%s\tColumns of %q should be separated at cell 5, got %d instead", failed, line, column)
+			} else {
+				t.Logf("\t%s\tColumns of %q should be separated at cell 5", succeed, line)
+			}
+		}
+	}
+}
+
+//TestDisplayWidth ...
+func TestDisplayWidth(t *testing.T) {
+	t.Log("Given a shaped line, its width in terminal cells should be returned")
+	{
+		for i, tt := range displayWidthTestCases {
+			width := DisplayWidth(tt.input)
+			t.Logf("\tTest: %d\t Measuring: %q", i, tt.input)
+			if width != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be %d cells wide, got %d instead", failed, tt.description, tt.expected, width)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be %d cells wide", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestShapeWithDirection ...
+func TestShapeWithDirection(t *testing.T) {
+	t.Log("Given a mixed string and a direction, shaping will be fixed for rendering")
+	{
+		for i, tt := range shapeWithDirectionTestCases {
+			shapedText := ShapeWithDirection(tt.input, tt.direction)
+			t.Logf("\tTest: %d\t Shaping: %s", i, tt.input)
+			if shapedText != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be updated to \n'%s'\n, got \n\"%s\"\n instead", failed, tt.description, tt.expected, shapedText)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be updated to %s", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestReorder ...
+func TestReorder(t *testing.T) {
+	t.Log("Given a logical string, it should be reordered visually")
+	{
+		for i, tt := range reorderTestCases {
+			visual := reorder(tt.input, tt.direction)
+			t.Logf("\tTest: %d\t Reordering: %q", i, tt.input)
+			if visual != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be reordered to %q, got %q instead", failed, tt.description, tt.expected, visual)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be reordered to %q", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
+//TestBidiCharacterTest ...
+func TestBidiCharacterTest(t *testing.T) {
+	t.Log("Given a paragraph from BidiCharacterTest.txt, its levels and visual order should match the test")
+	{
+		directions := map[string]Direction{"0": LeftToRight, "1": RightToLeft, "2": AutoDirection}
+		for i, tt := range bidiCharacterTestCases {
+			fields := strings.Split(tt.line, ";")
+			var runes []rune
+			for _, code := range strings.Fields(fields[0]) {
+				r, _ := strconv.ParseInt(code, 16, 32)
+				runes = append(runes, rune(r))
+			}
+			paragraph := newBidiParagraph(runes, directions[fields[1]])
+			levels := paragraph.lineLevels(0, len(runes))
+			var resolved, order []string
+			for i, level := range levels {
+				if isRemovedByX9(paragraph.initialTypes[i]) {
+					resolved = append(resolved, "x")
+				} else {
+					resolved = append(resolved, strconv.Itoa(level))
+				}
+			}
+			for _, i := range paragraph.visualOrder(0, len(runes)) {
+				if !isRemovedByX9(paragraph.initialTypes[i]) {
+					order = append(order, strconv.Itoa(i))
+				}
+			}
+			got := strings.Join([]string{fields[0], fields[1], strconv.Itoa(paragraph.level), strings.Join(resolved, " "), strings.Join(order, " ")}, ";")
+			t.Logf("\tTest: %d\t Resolving: %q", i, string(runes))
+			if got != tt.line {
+				t.Errorf("\t%s\t(%s)\tShould be resolved to %q, got %q instead", failed, tt.description, tt.line, got)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be resolved to %q", succeed, tt.description, tt.line)
+			}
+		}
+	}
+}
+
+//TestBidiMatchesXText ...
+func TestBidiMatchesXText(t *testing.T) {
+	t.Log("Given a random paragraph without brackets, the direction of its characters should match golang.org/x/text/unicode/bidi")
+	{
+		//x/text v0.3.6 doesn't pair brackets and doesn't override unmatched PDIs, they're covered by TestBidiCharacterTest
+		alphabet := []rune("aאب1١+$,:\u0651\u200b\t \u202a\u202b\u202c\u202d\u202e\u2066\u2067\u2068")
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 20000; i++ {
+			runes := make([]rune, 1+random.Intn(16))
+			isolates := 0
+			for k := range runes {
+				runes[k] = alphabet[random.Intn(len(alphabet))]
+				switch {
+				case isolates > 0 && random.Intn(4) == 0:
+					runes[k] = '\u2069'
+					isolates--
+				case runes[k] >= '\u2066':
+					isolates++
+				}
+			}
+			for _, dir := range []Direction{AutoDirection, RightToLeft} {
+				var reference bidi.Paragraph
+				if dir == RightToLeft {
+					reference.SetString(string(runes), bidi.DefaultDirection(bidi.RightToLeft))
+				} else {
+					reference.SetString(string(runes))
+				}
+				ordering, _ := reference.Order()
+				var expected []bool
+				for r := 0; r < ordering.NumRuns(); r++ {
+					run := ordering.Run(r)
+					for range run.String() {
+						expected = append(expected, run.Direction() == bidi.RightToLeft)
+					}
+				}
+				paragraph := newBidiParagraph(runes, dir)
+				for k, level := range paragraph.lineLevels(0, len(runes)) {
+					if !isRemovedByX9(paragraph.initialTypes[k]) && level%2 == 1 != expected[k] {
+						t.Fatalf("\t%s\tThe character %d of %q should be right to left: %t", failed, k, string(runes), expected[k])
+					}
+				}
+			}
+		}
+		t.Logf("\t%s\tThe directions should match", succeed)
+	}
+}
+
+//TestJoiningTypeOf ...
+func TestJoiningTypeOf(t *testing.T) {
+	t.Log("Given a letter, its joining type should match ArabicShaping.txt")
+	{
+		for i, tt := range joiningTypeTestCases {
+			t.Logf("\tTest: %d\t Checking joining type of %U", i, tt.input)
+			if got := joiningTypeOf(tt.input); got != tt.expected {
+				t.Errorf("\t%s\t(%s)\tShould be %d, got %d instead", failed, tt.description, tt.expected, got)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be %d", succeed, tt.description, tt.expected)
+			}
+		}
+	}
+}
+
 func TestIsArabicLetter(t *testing.T) {
 	t.Log("Given a letter, check if it's an arabic letter")
 	{
@@ -259,6 +1046,21 @@ func ExampleRemoveHarakat() {
 	// سنوات
 }
 
+func ExampleNormalizer() {
+	normalizer := NewNormalizer(NormalizeOptions{FoldAlef: true, StripShortVowels: true})
+	fmt.Println(normalizer.Normalize("أَمِيرة"))
+	// Output:
+	// اميرة
+}
+
+func ExampleNormalizeTransformer() {
+	reader := transform.NewReader(strings.NewReader("مَكْتَبَةُ الإسكندرية"), NormalizeTransformer())
+	normalized, _ := ioutil.ReadAll(reader)
+	fmt.Println(string(normalized))
+	// Output:
+	// مكتبه الاسكندريه
+}
+
 func ExampleSpellNumber() {
 	numberInWords := SpellNumber(100)
 	fmt.Println(numberInWords)
@@ -266,6 +1068,101 @@ func ExampleSpellNumber() {
 	// مئة
 }
 
+func ExampleSpellNumberWithOptions() {
+	fmt.Println(SpellNumberWithOptions(13, SpellOptions{Feminine: true}), "رسالة")
+	// Output:
+	// ثلاث عشرة رسالة
+}
+
+func ExampleSpellOptions() {
+	fmt.Println("دفعت", SpellNumberWithOptions(20, SpellOptions{Case: Accusative}), "ريالًا")
+	// Output:
+	// دفعت عشرين ريالًا
+}
+
+func ExampleSpellOrdinal() {
+	ordinal, _ := SpellOrdinal(25, OrdinalOptions{Definite: true})
+	fmt.Println("الفصل", ordinal)
+	// Output:
+	// الفصل الخامس و العشرون
+}
+
+func ExampleSpellQuantity() {
+	item := Noun{Singular: "عنصر", Dual: "عنصران", Plural: "عناصر", Accusative: "عنصرًا"}
+	for _, n := range []int{1, 2, 3, 15} {
+		fmt.Println(SpellQuantity(n, item))
+	}
+	// Output:
+	// عنصر واحد
+	// عنصران
+	// ثلاثة عناصر
+	// خمسة عشر عنصرًا
+}
+
+func ExampleSpellAmount() {
+	fmt.Println(SpellAmount(125025, SAR))
+	// Output:
+	// فقط ألف و مئتان و خمسون ريالًا سعوديًا و خمس و عشرون هللة لا غير
+}
+
+func ExampleSpellFraction() {
+	fraction, _ := SpellFraction(3, 4)
+	fmt.Println(fraction)
+	// Output:
+	// ثلاثة أرباع
+}
+
+func ExampleParseNumber() {
+	number, _ := ParseNumber("ألف ومائتان وخمسون")
+	fmt.Println(number)
+	// Output:
+	// 1250
+}
+
+func ExampleExtractNumbers() {
+	for _, number := range ExtractNumbers("دفع ١٬٢٥٠٫٥ ريال عن ثلاثة كتب") {
+		fmt.Println(number.Text, number.Value.FloatString(1))
+	}
+	// Output:
+	// ١٬٢٥٠٫٥ 1250.5
+	// ثلاثة 3.0
+}
+
+func ExampleFormatFloat() {
+	fmt.Println(FormatFloat(1234.5, -1, LocaleGulf))
+	fmt.Println(FormatFloat(1234.5, 2, LocaleMaghreb))
+	// Output:
+	// ١٬٢٣٤٫٥
+	// 1.234,50
+}
+
+func ExampleUnshape() {
+	fmt.Println(Normalize(Unshape("ﻡﻼﺳﻹﺍ")))
+	// Output:
+	// الاسلام
+}
+
+func ExampleJustify() {
+	fmt.Println(Justify("السلام عليكم", 15))
+	// Output:
+	// الســلام عليكــم
+}
+
+func ExampleShapeClusters() {
+	for _, cluster := range ShapeClusters("مَّد") {
+		fmt.Printf("%c %q\n", cluster.Glyph, cluster.Marks)
+	}
+	// Output:
+	// ﺪ []
+	// ﻣ ['َ' 'ّ']
+}
+
+func ExampleShapeForTerminal() {
+	fmt.Println(ShapeForTerminal("مَرْحَبًا\tHello"))
+	// Output:
+	// ﺎﺒﺣﺮﻣ	Hello
+}
+
 func ExampleIsArabicLetter() {
 	fmt.Println(IsArabicLetter('ص'))
 	// Output:
@@ -0,0 +1,147 @@
+//go:build ignore
+// +build ignore
+
+//This program generates tables.go from Unicode's ArabicShaping.txt, run it with go generate
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	version = flag.String("version", "13.0.0", "unicode version of ArabicShaping.txt")
+	input   = flag.String("input", "", "local copy of ArabicShaping.txt, downloaded from unicode.org if empty")
+	output  = flag.String("output", "tables.go", "generated file")
+)
+
+//blocks are the ranges of runes kept from ArabicShaping.txt
+var blocks = [][2]rune{
+	//Arabic
+	{0x0600, 0x06FF},
+	//Arabic Supplement
+	{0x0750, 0x077F},
+	//Zero width non-joiner and zero width joiner
+	{0x200C, 0x200D},
+}
+
+//joiningTypes maps the joining types of ArabicShaping.txt to their go constants
+var joiningTypes = map[string]string{
+	"U": "nonJoining",
+	"R": "rightJoining",
+	"L": "leftJoining",
+	"D": "dualJoining",
+	"C": "joinCausing",
+	"T": "transparent",
+}
+
+type joiningRange struct {
+	lo, hi      rune
+	joiningType string
+}
+
+func main() {
+	flag.Parse()
+
+	source, err := open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer source.Close()
+
+	ranges, err := parse(source)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by running \"go generate\" in github.com/abdullahdiaa/garabic. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package garabic\n\n")
+	fmt.Fprintf(&buf, "//joiningTypesVersion is the unicode version of ArabicShaping.txt used to generate the tables\n")
+	fmt.Fprintf(&buf, "const joiningTypesVersion = %q\n\n", *version)
+	fmt.Fprintf(&buf, "//joiningTypeRanges lists the joining types of arabic letters and join controls\n")
+	fmt.Fprintf(&buf, "var joiningTypeRanges = []joiningRange{\n")
+	for _, r := range ranges {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %s},\n", r.lo, r.hi, r.joiningType)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+//open returns the local ArabicShaping.txt or downloads it
+func open() (io.ReadCloser, error) {
+	if *input != "" {
+		return os.Open(*input)
+	}
+	url := fmt.Sprintf("https://www.unicode.org/Public/%s/ucd/ArabicShaping.txt", *version)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+//parse reads the joining types of ArabicShaping.txt and merges adjacent runes of the same type
+func parse(source io.Reader) ([]joiningRange, error) {
+	var ranges []joiningRange
+	scanner := bufio.NewScanner(source)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 3 {
+			continue
+		}
+		code, err := strconv.ParseUint(strings.TrimSpace(fields[0]), 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		r := rune(code)
+		joiningType, ok := joiningTypes[strings.TrimSpace(fields[2])]
+		if !ok {
+			return nil, fmt.Errorf("unknown joining type %q for %U", fields[2], r)
+		}
+		if !inBlocks(r) {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].hi == r-1 && ranges[n-1].joiningType == joiningType {
+			ranges[n-1].hi = r
+			continue
+		}
+		ranges = append(ranges, joiningRange{r, r, joiningType})
+	}
+	return ranges, scanner.Err()
+}
+
+//inBlocks checks if the rune is part of the kept blocks
+func inBlocks(r rune) bool {
+	for _, block := range blocks {
+		if r >= block[0] && r <= block[1] {
+			return true
+		}
+	}
+	return false
+}
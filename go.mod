@@ -2,4 +2,7 @@ module github.com/abdullahdiaa/garabic
 
 go 1.16
 
-require golang.org/x/text v0.3.6
+require (
+	golang.org/x/image v0.0.0-20210628002857-a66eb6448b8d
+	golang.org/x/text v0.3.6
+)
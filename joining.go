@@ -0,0 +1,60 @@
+package garabic
+
+//go:generate go run gen.go
+
+import (
+	"sort"
+	"unicode"
+)
+
+//joiningType is the Unicode joining type of a letter (ArabicShaping.txt)
+type joiningType byte
+
+const (
+	//nonJoining letters (U) don't connect to their neighbors, like hamza and ZWNJ
+	nonJoining joiningType = iota
+	//rightJoining letters (R) connect to the preceding letter only, like alef and dal
+	rightJoining
+	//leftJoining letters (L) connect to the following letter only
+	leftJoining
+	//dualJoining letters (D) connect to both sides, like beh and seen
+	dualJoining
+	//joinCausing letters (C) force the connection of their neighbors, like tatweel and ZWJ
+	joinCausing
+	//transparent letters (T) are skipped when connecting letters, like harakat
+	transparent
+)
+
+//joiningRange holds the joining type of the runes between lo and hi
+type joiningRange struct {
+	lo, hi      rune
+	joiningType joiningType
+}
+
+//joiningTypeOf returns the joining type of a rune, runes not listed in ArabicShaping.txt
+//are transparent if they are marks or format characters and non joining otherwise
+func joiningTypeOf(r rune) joiningType {
+	i := sort.Search(len(joiningTypeRanges), func(i int) bool { return joiningTypeRanges[i].hi >= r })
+	if i < len(joiningTypeRanges) && joiningTypeRanges[i].lo <= r {
+		return joiningTypeRanges[i].joiningType
+	}
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return transparent
+	}
+	return nonJoining
+}
+
+//joinsFollowing checks if the letter connects to the letter after it in logical order
+func (t joiningType) joinsFollowing() bool {
+	return t == dualJoining || t == leftJoining || t == joinCausing
+}
+
+//joinsPreceding checks if the letter connects to the letter before it in logical order
+func (t joiningType) joinsPreceding() bool {
+	return t == dualJoining || t == rightJoining || t == joinCausing
+}
+
+//isJoinControl checks if the rune is a zero width joiner or non-joiner
+func isJoinControl(r rune) bool {
+	return r == '\u200C' || r == '\u200D'
+}
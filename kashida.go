@@ -0,0 +1,248 @@
+package garabic
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//JustifyOptions controls how Justify stretches a line with tatweel
+type JustifyOptions struct {
+	//Width is the target width of the line, in characters or in the units returned by Measure
+	Width int
+	//Measure returns the width of shaped text in logical order, e.g. the advance of a font face
+	//(font.MeasureString(face, s).Round()). Characters are counted when it is nil, harakat excluded
+	Measure func(string) int
+	//Shaped returns the justified line shaped and reordered like Shape instead of logical text
+	Shaped bool
+}
+
+//Kashida priorities, the lowest value is the preferred position in a word
+const (
+	//kashidaAfterTatweel stretches a tatweel already present in the text
+	kashidaAfterTatweel = iota + 1
+	//kashidaAfterSeen stretches the connection after seen, sheen, sad and dad
+	kashidaAfterSeen
+	//kashidaBeforeFinalHae stretches the connection before final teh marbuta, hae and dal
+	kashidaBeforeFinalHae
+	//kashidaBeforeFinalAlef stretches the connection before final alef, tah, lam, kaf and gaf
+	kashidaBeforeFinalAlef
+	//kashidaBeforeMedialBeh stretches the connection before a medial beh followed by final reh or yae
+	kashidaBeforeMedialBeh
+	//kashidaBeforeFinalWaw stretches the connection before final waw, ain, qaf and feh
+	kashidaBeforeFinalWaw
+	//kashidaConnection stretches any other connection
+	kashidaConnection
+)
+
+//Letters used by the kashida priority rules
+var (
+	kashidaSeenLetters   = []rune{'س', 'ش', 'ص', 'ض'}
+	kashidaHaeLetters    = []rune{'ة', 'ه', 'د', 'ذ', 'ۀ', 'ہ', 'ە'}
+	kashidaAlefLetters   = []rune{'ا', 'أ', 'إ', 'آ', 'ٱ', 'ط', 'ظ', 'ل', 'ك', 'ک', 'گ'}
+	kashidaBehLetters    = []rune{'ب', 'ت', 'ث', 'ن', 'ي', 'ئ', 'ى', 'ی', 'پ', 'ٹ', 'ٻ'}
+	kashidaRehYaeLetters = []rune{'ر', 'ز', 'ژ', 'ڑ', 'ي', 'ى', 'ی', 'ئ'}
+	kashidaWawLetters    = []rune{'و', 'ؤ', 'ع', 'غ', 'ق', 'ف', 'ۆ', 'ۇ', 'ۋ', 'ڤ'}
+)
+
+//Justify stretches an arabic line to width characters by inserting tatweel (kashida) between
+//connected letters, the line is returned unchanged when it is already wider or can't be stretched
+func Justify(input string, width int) string {
+	return JustifyWithOptions(input, JustifyOptions{Width: width})
+}
+
+//JustifyWithOptions stretches an arabic line to opts.Width by inserting tatweel (kashida) between
+//connected letters. Each word gets a kashida at its position of highest priority:
+// 1. after a tatweel of the text
+// 2. after seen, sheen, sad or dad
+// 3. before final teh marbuta, hae or dal
+// 4. before final alef, tah, lam, kaf or gaf (lam alef ligatures are never split)
+// 5. before a medial beh followed by final reh or yae
+// 6. before final waw, ain, qaf or feh
+// 7. between any other connected letters
+//
+// The name of God (الله) is never stretched. Words with the highest priority are stretched first,
+// and the kashidas are repeated until the next one would exceed the width. The line is returned
+// unchanged when it is already wider or has no connected letters
+func JustifyWithOptions(input string, opts JustifyOptions) string {
+	measure := opts.Measure
+	if measure == nil {
+		measure = countCharacters
+	}
+	letters := []rune(input)
+	points := kashidaPoints(letters)
+	counts := make([]int, len(points))
+
+	width := measure(shapeText(input))
+	for full := len(points) == 0; !full; {
+		for i := range points {
+			counts[i]++
+			next := measure(shapeText(insertKashidas(letters, points, counts)))
+			//Stop when the kashida overflows the line or doesn't make it any wider
+			if next > opts.Width || next <= width {
+				counts[i]--
+				full = true
+				break
+			}
+			width = next
+		}
+	}
+
+	justified := insertKashidas(letters, points, counts)
+	if opts.Shaped {
+		return Shape(justified)
+	}
+	return justified
+}
+
+//kashidaPoint is a position of the line where tatweel can be inserted
+type kashidaPoint struct {
+	//index is the rune before which the tatweel is inserted
+	index    int
+	priority int
+}
+
+//kashidaPoints returns the best kashida position of each word ordered by priority,
+//words of the same priority keep their order in the line
+func kashidaPoints(letters []rune) []kashidaPoint {
+	types := make([]joiningType, len(letters))
+	for i, letter := range letters {
+		types[i] = joiningTypeOf(letter)
+	}
+
+	var points []kashidaPoint
+	best := kashidaPoint{index: -1}
+	start := 0
+	for i, letter := range letters {
+		if !IsArabicLetter(letter) && !isJoinControl(letter) {
+			//End of word
+			if best.index >= 0 && !isAllahWord(letters[start:i]) {
+				points = append(points, best)
+			}
+			best = kashidaPoint{index: -1}
+			start = i + 1
+			continue
+		}
+		if priority := kashidaPriority(letters, types, i); priority > 0 {
+			//The last position of the word wins between positions of the same priority
+			if best.index < 0 || priority <= best.priority {
+				best = kashidaPoint{index: i, priority: priority}
+			}
+		}
+	}
+	if best.index >= 0 && !isAllahWord(letters[start:]) {
+		points = append(points, best)
+	}
+
+	sort.SliceStable(points, func(i, j int) bool { return points[i].priority < points[j].priority })
+	return points
+}
+
+//_allahPrefixes are the letters written before لله in the name of God, e.g. الله, لله, بالله and والله
+var _allahPrefixes = []string{"", "ا", "و", "ف", "وا", "فا", "با", "تا", "وبا", "فبا"}
+
+//isAllahWord checks if a word is the name of God (لفظ الجلالة) with its prefixes or اللهم,
+//it's never stretched with tatweel
+func isAllahWord(word []rune) bool {
+	var skeleton strings.Builder
+	for _, letter := range word {
+		switch {
+		case letter == AlefWaslah:
+			skeleton.WriteRune('ا')
+		case letter != Tatweel && joiningTypeOf(letter) != transparent && !isJoinControl(letter):
+			skeleton.WriteRune(letter)
+		}
+	}
+	stem := strings.TrimSuffix(skeleton.String(), "م")
+	if !strings.HasSuffix(stem, "لله") {
+		return false
+	}
+	prefix := strings.TrimSuffix(stem, "لله")
+	for _, p := range _allahPrefixes {
+		if prefix == p {
+			return true
+		}
+	}
+	return false
+}
+
+//kashidaPriority returns the priority of inserting a tatweel before the letter i, or 0 if the letter
+//isn't connected to the letter before it. Tatweel goes after the harakat of the previous letter
+func kashidaPriority(letters []rune, types []joiningType, i int) int {
+	if types[i] == transparent {
+		return 0
+	}
+	previous := adjacentLetter(types, i, -1)
+	if previous < 0 || !types[previous].joinsFollowing() || !types[i].joinsPreceding() {
+		return 0
+	}
+	prev, letter := letters[previous], letters[i]
+	if isJoinControl(prev) || isJoinControl(letter) {
+		return 0
+	}
+	//Splitting a lam alef ligature would change the letters drawn
+	if _, ok := lamAlefLigatures[letter]; ok && prev == Lam {
+		return 0
+	}
+	next := adjacentLetter(types, i, 1)
+	final := isFinalLetter(types, i)
+
+	switch {
+	case prev == Tatweel:
+		return kashidaAfterTatweel
+	case containsRune(kashidaSeenLetters, prev):
+		return kashidaAfterSeen
+	case final && containsRune(kashidaHaeLetters, letter):
+		return kashidaBeforeFinalHae
+	case final && containsRune(kashidaAlefLetters, letter):
+		return kashidaBeforeFinalAlef
+	case !final && containsRune(kashidaBehLetters, letter) && isFinalLetter(types, next) &&
+		containsRune(kashidaRehYaeLetters, letters[next]):
+		return kashidaBeforeMedialBeh
+	case final && containsRune(kashidaWawLetters, letter):
+		return kashidaBeforeFinalWaw
+	}
+	return kashidaConnection
+}
+
+//isFinalLetter checks if the letter i connects to the letter before it only
+func isFinalLetter(types []joiningType, i int) bool {
+	next := adjacentLetter(types, i, 1)
+	return next < 0 || !types[next].joinsPreceding() || !types[i].joinsFollowing()
+}
+
+//insertKashidas inserts counts[i] tatweels at each kashida point
+func insertKashidas(letters []rune, points []kashidaPoint, counts []int) string {
+	inserted := make(map[int]int, len(points))
+	for i, point := range points {
+		inserted[point.index] += counts[i]
+	}
+	var output strings.Builder
+	for i, letter := range letters {
+		output.WriteString(strings.Repeat(string(Tatweel), inserted[i]))
+		output.WriteRune(letter)
+	}
+	return output.String()
+}
+
+//countCharacters counts the characters of shaped text, harakat, other marks and format characters
+//like the zero width joiner take no space
+func countCharacters(shaped string) int {
+	count := 0
+	for _, r := range shaped {
+		if joiningTypeOf(r) != transparent && !unicode.Is(unicode.Cf, r) {
+			count++
+		}
+	}
+	return count
+}
+
+//containsRune checks if a rune is present in a slice
+func containsRune(runes []rune, r rune) bool {
+	for _, v := range runes {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,319 @@
+package garabic
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// Letters folded by the Normalizer in addition to the alef/yae/hae group
+const (
+	//Waw => و
+	Waw = 'و'
+	//WawHamzaAbove => ؤ
+	WawHamzaAbove = 'ؤ'
+	//YaeHamzaAbove => ئ
+	YaeHamzaAbove = 'ئ'
+	//Tatweel => ـ
+	Tatweel = 'ـ'
+)
+
+//NormalizeOptions selects which foldings a Normalizer applies to the text
+type NormalizeOptions struct {
+	//FoldAlef replaces آ أ إ with ا
+	FoldAlef bool
+	//FoldAlefWaslah replaces ٱ with ا
+	FoldAlefWaslah bool
+	//FoldYae replaces ى with ي
+	FoldYae bool
+	//FoldTehMarbuta replaces ة with ه
+	FoldTehMarbuta bool
+	//FoldHamzaOnWaw replaces ؤ with و
+	FoldHamzaOnWaw bool
+	//FoldHamzaOnYae replaces ئ with ي
+	FoldHamzaOnYae bool
+	//StripTatweel removes ـ
+	StripTatweel bool
+	//StripTanwin removes tanwin ً ٌ ٍ
+	StripTanwin bool
+	//StripShortVowels removes fathah, dammah and kasrah َ ُ ِ
+	StripShortVowels bool
+	//StripShadda removes shaddah ّ
+	StripShadda bool
+	//StripSukun removes sukun ْ
+	StripSukun bool
+	//StripDaggerAlef removes alif khanjariyah ٰ
+	StripDaggerAlef bool
+}
+
+//RemoveHarakatOptions are the options used by RemoveHarakat
+var RemoveHarakatOptions = NormalizeOptions{
+	FoldAlefWaslah:   true,
+	StripTatweel:     true,
+	StripTanwin:      true,
+	StripShortVowels: true,
+	StripShadda:      true,
+	StripSukun:       true,
+	StripDaggerAlef:  true,
+}
+
+//NormalizeDefaultOptions are the options used by Normalize
+var NormalizeDefaultOptions = NormalizeOptions{
+	FoldAlef:         true,
+	FoldAlefWaslah:   true,
+	FoldYae:          true,
+	FoldTehMarbuta:   true,
+	StripTatweel:     true,
+	StripTanwin:      true,
+	StripShortVowels: true,
+	StripShadda:      true,
+	StripSukun:       true,
+	StripDaggerAlef:  true,
+}
+
+//Normalizer applies a fixed set of foldings to arabic text, it is safe for concurrent use.
+//It implements transform.SpanningTransformer so it can be used with transform.NewReader,
+//transform.NewWriter and transform.Chain
+type Normalizer struct {
+	opts  NormalizeOptions
+	strip *unicode.RangeTable
+}
+
+//NewNormalizer creates a Normalizer from the given options
+func NewNormalizer(opts NormalizeOptions) *Normalizer {
+	//Pick the stripped ranges out of the normalizable table
+	strip := &unicode.RangeTable{}
+	for _, r := range normalizable.R16 {
+		if opts.strips(rune(r.Lo)) {
+			strip.R16 = append(strip.R16, r)
+		}
+	}
+	return &Normalizer{opts: opts, strip: strip}
+}
+
+//Options returns the options the normalizer was created with
+func (n *Normalizer) Options() NormalizeOptions {
+	return n.opts
+}
+
+//Normalize applies the normalizer foldings to the input
+func (n *Normalizer) Normalize(input string) string {
+	var output strings.Builder
+	output.Grow(len(input))
+	for _, r := range input {
+		if r = n.mapRune(r); r >= 0 {
+			output.WriteRune(r)
+		}
+	}
+	return output.String()
+}
+
+//NormalizeAligned applies the normalizer foldings to the input and returns an alignment
+//that maps every rune of the normalized text back to the original text
+func (n *Normalizer) NormalizeAligned(input string) (string, Alignment) {
+	var output strings.Builder
+	output.Grow(len(input))
+	alignment := make(Alignment, 0, utf8.RuneCountInString(input))
+	for i, ri := 0, 0; i < len(input); ri++ {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if r = n.mapRune(r); r >= 0 {
+			start := output.Len()
+			output.WriteRune(r)
+			segment := Segment{
+				Start: start, End: output.Len(),
+				OrigStart: i, OrigEnd: i + size,
+				OrigRuneStart: ri, OrigRuneEnd: ri + 1,
+			}
+			if len(alignment) == 0 {
+				//Removed runes before the first kept rune are attached to it
+				segment.OrigStart, segment.OrigRuneStart = 0, 0
+			}
+			alignment = append(alignment, segment)
+		} else if len(alignment) > 0 {
+			//Removed runes are attached to the rune preceding them
+			alignment[len(alignment)-1].OrigEnd = i + size
+			alignment[len(alignment)-1].OrigRuneEnd = ri + 1
+		}
+		i += size
+	}
+	return output.String(), alignment
+}
+
+//Reset implements transform.Transformer, the normalizer has no state to reset
+func (n *Normalizer) Reset() {}
+
+//Transform implements transform.Transformer
+func (n *Normalizer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := rune(src[nSrc]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(src[nSrc:])
+			//Wait for the rest of an incomplete rune
+			if size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+				err = transform.ErrShortSrc
+				break
+			}
+		}
+		mapped := n.mapRune(r)
+		if mapped < 0 {
+			nSrc += size
+			continue
+		}
+		//Unchanged runes are copied as is, invalid bytes are replaced with utf8.RuneError like Normalize does
+		if mapped == r && !isInvalidRune(r, size) {
+			if nDst+size > len(dst) {
+				err = transform.ErrShortDst
+				break
+			}
+			nDst += copy(dst[nDst:], src[nSrc:nSrc+size])
+			nSrc += size
+			continue
+		}
+		if nDst+utf8.RuneLen(mapped) > len(dst) {
+			err = transform.ErrShortDst
+			break
+		}
+		nDst += utf8.EncodeRune(dst[nDst:], mapped)
+		nSrc += size
+	}
+	return nDst, nSrc, err
+}
+
+//Span implements transform.SpanningTransformer
+func (n *Normalizer) Span(src []byte, atEOF bool) (int, error) {
+	for i := 0; i < len(src); {
+		r, size := rune(src[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(src[i:])
+			if size == 1 && !atEOF && !utf8.FullRune(src[i:]) {
+				return i, transform.ErrShortSrc
+			}
+		}
+		if n.mapRune(r) != r || isInvalidRune(r, size) {
+			return i, transform.ErrEndOfSpan
+		}
+		i += size
+	}
+	return len(src), nil
+}
+
+//isInvalidRune checks if a decoded rune is an invalid byte rather than an encoded utf8.RuneError
+func isInvalidRune(r rune, size int) bool {
+	return r == utf8.RuneError && size == 1
+}
+
+//mapRune returns the folded version of r, or -1 if r should be removed
+func (n *Normalizer) mapRune(r rune) rune {
+	if unicode.Is(n.strip, r) {
+		return -1
+	}
+	switch r {
+	case AlefMad, AlefHamzaAbove, AlefHamzaBelow:
+		if n.opts.FoldAlef {
+			return Alef
+		}
+	case AlefWaslah:
+		if n.opts.FoldAlefWaslah {
+			return Alef
+		}
+	case DotlessYae:
+		if n.opts.FoldYae {
+			return Yae
+		}
+	case TehMarbuta:
+		if n.opts.FoldTehMarbuta {
+			return Hae
+		}
+	case WawHamzaAbove:
+		if n.opts.FoldHamzaOnWaw {
+			return Waw
+		}
+	case YaeHamzaAbove:
+		if n.opts.FoldHamzaOnYae {
+			return Yae
+		}
+	}
+	return r
+}
+
+//strips checks if a normalizable rune is removed with the options
+func (o NormalizeOptions) strips(r rune) bool {
+	switch r {
+	case Tatweel:
+		return o.StripTatweel
+	//TanwinFatḥah, TanwinDammah, TanwinKasrah
+	case 'ً', 'ٌ', 'ٍ':
+		return o.StripTanwin
+	//Fatḥah, Dammah, Kasrah
+	case 'َ', 'ُ', 'ِ':
+		return o.StripShortVowels
+	//Shaddah
+	case 'ّ':
+		return o.StripShadda
+	//Sukun
+	case 'ْ':
+		return o.StripSukun
+	//DaggerAlif
+	case 'ٰ':
+		return o.StripDaggerAlef
+	}
+	return false
+}
+
+//Segment links a rune of a normalized text to the part of the original text it came from,
+//the original part includes the harakat and tatweel removed after the rune, and the ones removed
+//at the start of the text for the first rune
+type Segment struct {
+	//Start and End are the byte offsets of the rune in the normalized text
+	Start, End int
+	//OrigStart and OrigEnd are byte offsets in the original text
+	OrigStart, OrigEnd int
+	//OrigRuneStart and OrigRuneEnd are rune offsets in the original text
+	OrigRuneStart, OrigRuneEnd int
+}
+
+//Alignment maps a normalized text back to its original text, it holds a segment for each
+//rune of the normalized text so the segment of the i-th rune is Alignment[i]
+type Alignment []Segment
+
+//Original converts the byte range [start, end) of the normalized text into a byte range of the original text
+func (a Alignment) Original(start, end int) (int, int) {
+	if len(a) == 0 {
+		return 0, 0
+	}
+	first := sort.Search(len(a), func(i int) bool { return a[i].End > start })
+	if first == len(a) {
+		return a[first-1].OrigEnd, a[first-1].OrigEnd
+	}
+	if end <= start {
+		return a[first].OrigStart, a[first].OrigStart
+	}
+	last := sort.Search(len(a), func(i int) bool { return a[i].End >= end })
+	if last == len(a) {
+		last--
+	}
+	return a[first].OrigStart, a[last].OrigEnd
+}
+
+//OriginalRunes converts the rune range [start, end) of the normalized text into a rune range of the original text
+func (a Alignment) OriginalRunes(start, end int) (int, int) {
+	if len(a) == 0 {
+		return 0, 0
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(a) {
+		return a[len(a)-1].OrigRuneEnd, a[len(a)-1].OrigRuneEnd
+	}
+	if end <= start {
+		return a[start].OrigRuneStart, a[start].OrigRuneStart
+	}
+	if end > len(a) {
+		end = len(a)
+	}
+	return a[start].OrigRuneStart, a[end-1].OrigRuneEnd
+}
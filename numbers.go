@@ -0,0 +1,465 @@
+package garabic
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+//ErrNumberTooLarge is returned when a number is beyond the largest scale that can be spelled,
+//only numbers below 10^36 are spelled
+var ErrNumberTooLarge = errors.New("garabic: number is too large to spell")
+
+//Number groups in Arabic
+var _zeroToNine = []string{
+	"صفر", "واحد", "اثنان", "ثلاثة", "أربعة",
+	"خمسة", "ستة", "سبعة", "ثمانية", "تسعة",
+}
+
+var _elevenToNineteen = []string{
+	"عشرة", "أحد عشر", "اثنا عشر", "ثلاثة عشر", "أربعة عشر",
+	"خمسة عشر", "ستة عشر", "سبعة عشر", "ثمانية عشر", "تسعة عشر",
+}
+
+//Number groups counting a feminine noun, three to ten take the opposite gender of the noun
+var _zeroToNineFeminine = []string{
+	"صفر", "واحدة", "اثنتان", "ثلاث", "أربع",
+	"خمس", "ست", "سبع", "ثماني", "تسع",
+}
+
+var _elevenToNineteenFeminine = []string{
+	"عشر", "إحدى عشرة", "اثنتا عشرة", "ثلاث عشرة", "أربع عشرة",
+	"خمس عشرة", "ست عشرة", "سبع عشرة", "ثماني عشرة", "تسع عشرة",
+}
+
+var _tens = []string{
+	"", "", "عشرون", "ثلاثون", "أربعون", "خمسون",
+	"ستون", "سبعون", "ثمانون", "تسعون",
+}
+var _hundreds = []string{
+	"", "مئة", "مئتان", "ثلاثمئة", "أربعمئة", "خمسمئة", "ستمئة", "سبعمئة", "ثمانمئة", "تسعمئة",
+}
+
+//Noun holds the forms of a noun counted by a number (تمييز العدد), the forms can be phrases
+//with the adjectives of the noun, e.g. ريال سعودي
+type Noun struct {
+	//Singular follows one and the hundreds, e.g. ريال سعودي
+	Singular string
+	//Dual replaces two, e.g. ريالان سعوديان
+	Dual string
+	//Plural follows three to ten, e.g. ريالات سعودية
+	Plural string
+	//Accusative is the singular following eleven to ninety nine, e.g. ريالًا سعوديًا
+	Accusative string
+	//Feminine is the gender of the noun, numbers from three to ten take the opposite gender
+	Feminine bool
+}
+
+//_scaleNumbers are the names of the powers of 1000 in the short scale
+var _scaleNumbers = []Noun{
+	{},
+	{"ألف", "ألفان", "آلاف", "ألفًا", false},
+	{"مليون", "مليونان", "ملايين", "مليونًا", false},
+	{"مليار", "ملياران", "مليارات", "مليارًا", false},
+	{"تريليون", "تريليونان", "تريليونات", "تريليونًا", false},
+	{"كوادريليون", "كوادريليونان", "كوادريليونات", "كوادريليونًا", false},
+	{"كوينتليون", "كوينتليونان", "كوينتليونات", "كوينتليونًا", false},
+	{"سكستليون", "سكستليونان", "سكستليونات", "سكستليونًا", false},
+	{"سبتليون", "سبتليونان", "سبتليونات", "سبتليونًا", false},
+	{"أوكتليون", "أوكتليونان", "أوكتليونات", "أوكتليونًا", false},
+	{"نونليون", "نونليونان", "نونليونات", "نونليونًا", false},
+	{"ديسليون", "ديسليونان", "ديسليونات", "ديسليونًا", false},
+}
+
+//_hundredsConstruct are the hundreds followed by the noun they count (إضافة)
+var _hundredsConstruct = []string{
+	"", "مئة", "مئتا", "ثلاثمئة", "أربعمئة", "خمسمئة", "ستمئة", "سبعمئة", "ثمانمئة", "تسعمئة",
+}
+
+//_obliqueForms are the accusative and genitive forms of the number words that don't end like a dual or a plural
+var _obliqueForms = map[string]string{
+	"اثنا عشر":   "اثني عشر",
+	"اثنتا عشرة": "اثنتي عشرة",
+	"مئتا":       "مئتي",
+}
+
+//Case is the grammatical case (إعراب) of a spelled number in its sentence
+type Case int
+
+const (
+	//Nominative case (مرفوع), e.g. جاء عشرون رجلًا
+	Nominative Case = iota
+	//Accusative case (منصوب), e.g. دفعت عشرين ريالًا
+	Accusative
+	//Genitive case (مجرور), e.g. بعد عشرين يومًا
+	Genitive
+)
+
+//SpellOptions controls the forms of the spelled numbers
+type SpellOptions struct {
+	//Feminine spells the number counting a feminine noun, e.g. ثلاث رسائل instead of ثلاثة كتب.
+	//Scale words are masculine, so only the last group of three digits agrees with the noun
+	Feminine bool
+	//Case is the grammatical case of the number, twos, tens and dual scale words end with ين
+	//instead of ان and ون in the accusative and genitive
+	Case Case
+}
+
+// SpellNumber will transform a number into a readable arabic version
+func SpellNumber(input int) string {
+	return SpellInt64(int64(input))
+}
+
+//SpellNumberWithOptions will transform a number into a readable arabic version with the forms of opts
+func SpellNumberWithOptions(input int, opts SpellOptions) string {
+	return spellInt64(int64(input), opts)
+}
+
+//SpellQuantity will transform a number into a readable arabic version with the noun it counts,
+//e.g. كتاب واحد، كتابان، ثلاثة كتب، أحد عشر كتابًا، مئة كتاب
+func SpellQuantity(input int, noun Noun) string {
+	return SpellQuantityInCase(input, noun, Nominative)
+}
+
+//SpellQuantityInCase will transform a number into a readable arabic version with the noun it counts
+//in the grammatical case, e.g. اشتريت كتابين
+func SpellQuantityInCase(input int, noun Noun, c Case) string {
+	if input < 0 {
+		return spellQuantity(digitGroups(uint64(-int64(input))), true, noun, c)
+	}
+	return spellQuantity(digitGroups(uint64(input)), false, noun, c)
+}
+
+//SpellInt64 will transform an int64 into a readable arabic version
+func SpellInt64(input int64) string {
+	return spellInt64(input, SpellOptions{})
+}
+
+//spellInt64 spells an int64 with the forms of opts
+func spellInt64(input int64, opts SpellOptions) string {
+	if input < 0 {
+		//The magnitude of math.MinInt64 only fits in an uint64
+		return spellGroups(digitGroups(uint64(-input)), true, opts, false)
+	}
+	return spellGroups(digitGroups(uint64(input)), false, opts, false)
+}
+
+//SpellUint64 will transform an uint64 into a readable arabic version
+func SpellUint64(input uint64) string {
+	return spellGroups(digitGroups(input), false, SpellOptions{}, false)
+}
+
+//SpellBigInt will transform a big integer into a readable arabic version, ErrNumberTooLarge is returned
+//when it has more groups of three digits than the named scales and ErrInvalidNumber when it's nil
+func SpellBigInt(input *big.Int) (string, error) {
+	if input == nil {
+		return "", ErrInvalidNumber
+	}
+	magnitude := new(big.Int).Abs(input)
+	thousand := big.NewInt(1000)
+	group := new(big.Int)
+	var groups []int
+	for magnitude.Sign() > 0 {
+		if len(groups) == len(_scaleNumbers) {
+			return "", ErrNumberTooLarge
+		}
+		magnitude.DivMod(magnitude, thousand, group)
+		groups = append(groups, int(group.Int64()))
+	}
+	return spellGroups(groups, input.Sign() < 0, SpellOptions{}, false), nil
+}
+
+//digitGroups splits a number into groups of three digits, from the lowest to the highest scale
+func digitGroups(input uint64) []int {
+	groups := []int{}
+	for input > 0 {
+		groups = append(groups, int(input%1000))
+		input = input / 1000
+	}
+	return groups
+}
+
+//spellGroups spells a number from its groups of three digits, each group counts its scale word
+//and the groups are joined by و from the highest scale to the lowest. construct spells a number
+//ending with two zeros in construct (إضافة) with the noun following it, e.g. ألفا ريال
+func spellGroups(groups []int, negative bool, opts SpellOptions, construct bool) string {
+	var stringOfNum []string
+	if negative {
+		stringOfNum = append(stringOfNum, "سالب")
+	}
+
+	//lowest is the last group spelled
+	lowest := 0
+	for lowest < len(groups)-1 && groups[lowest] == 0 {
+		lowest++
+	}
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		switch {
+		case groups[i] == 0:
+		case i == 0 && construct:
+			parts = append(parts, inCase(_hundredsConstruct[groups[i]/100], opts.Case))
+		case i == 0:
+			parts = append(parts, spellHundreds(groups[i], opts))
+		default:
+			parts = append(parts, spellCounted(groups[i], _scaleNumbers[i], opts.Case, construct && i == lowest))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, _zeroToNine[0])
+	}
+
+	stringOfNum = append(stringOfNum, strings.Join(parts, " و "))
+	return strings.Join(stringOfNum, " ")
+}
+
+//spellHundreds spells a number between 1 and 999 with the forms of opts
+func spellHundreds(number int, opts SpellOptions) string {
+	var parts []string
+	if hundreds := number / 100; hundreds > 0 {
+		parts = append(parts, inCase(_hundreds[hundreds], opts.Case))
+	}
+	if rest := number % 100; rest > 0 {
+		parts = append(parts, spellTens(rest, opts))
+	}
+	return strings.Join(parts, " و ")
+}
+
+//spellTens spells a number between 1 and 99 with the forms of opts, units come before tens
+func spellTens(number int, opts SpellOptions) string {
+	units, teens := _zeroToNine, _elevenToNineteen
+	if opts.Feminine {
+		units, teens = _zeroToNineFeminine, _elevenToNineteenFeminine
+	}
+	switch tens := number / 10; {
+	case tens == 0:
+		return inCase(units[number%10], opts.Case)
+	case tens == 1:
+		return inCase(teens[number%10], opts.Case)
+	case number%10 == 0:
+		return inCase(_tens[tens], opts.Case)
+	case number%10 == 1 && opts.Feminine:
+		//إحدى is only used with the tens
+		return fmt.Sprintf("إحدى و %s", inCase(_tens[tens], opts.Case))
+	default:
+		return fmt.Sprintf("%s و %s", inCase(units[number%10], opts.Case), inCase(_tens[tens], opts.Case))
+	}
+}
+
+//spellCounted spells a number between 1 and 999 with the word it counts (تمييز العدد):
+// 1 is the singular word alone and 2 its dual
+// 3 to 10 are followed by the plural
+// 11 to 99 are followed by the accusative singular
+// the hundreds are followed by the singular, ones and twos after them repeat the word
+//
+//construct spells the word in construct with a noun following it, the dual loses its ن
+//and the accusative its tanween, e.g. ألفا ريال and أحد عشر ألف ريال
+func spellCounted(number int, word Noun, c Case, construct bool) string {
+	hundreds, rest := number/100, number%100
+	opts := SpellOptions{Feminine: word.Feminine, Case: c}
+	switch {
+	case number == 1:
+		return word.Singular
+	case number == 2 && construct:
+		return strings.TrimSuffix(dualInCase(word.Dual, c), "ن")
+	case number == 2:
+		return dualInCase(word.Dual, c)
+	case number <= 10:
+		return fmt.Sprintf("%s %s", spellTens(number, opts), word.Plural)
+	case number < 100 && construct:
+		return fmt.Sprintf("%s %s", spellTens(number, opts), word.Singular)
+	case number < 100:
+		return fmt.Sprintf("%s %s", spellTens(number, opts), word.Accusative)
+	case rest == 0:
+		return fmt.Sprintf("%s %s", inCase(_hundredsConstruct[hundreds], c), word.Singular)
+	case rest <= 2:
+		return fmt.Sprintf("%s %s و %s", inCase(_hundredsConstruct[hundreds], c), word.Singular, spellCounted(rest, word, c, construct))
+	default:
+		return fmt.Sprintf("%s و %s", inCase(_hundreds[hundreds], c), spellCounted(rest, word, c, construct))
+	}
+}
+
+//spellQuantity spells a number from its groups of three digits with the noun it counts,
+//the form of the noun follows the last two digits of the number
+func spellQuantity(groups []int, negative bool, noun Noun, c Case) string {
+	var stringOfNum []string
+	if negative {
+		stringOfNum = append(stringOfNum, "سالب")
+	}
+
+	opts := SpellOptions{Feminine: noun.Feminine, Case: c}
+	rest := 0
+	if len(groups) > 0 {
+		rest = groups[0] % 100
+	}
+	//whole is the number when it's lower than 1000
+	whole := rest
+	if len(groups) == 1 {
+		whole = groups[0]
+	} else if len(groups) > 1 {
+		whole = 1000
+	}
+
+	switch {
+	case len(groups) == 0:
+		stringOfNum = append(stringOfNum, _zeroToNine[0], noun.Singular)
+	case whole == 1:
+		//One follows the noun as an adjective
+		one := "واحد"
+		if noun.Feminine {
+			one = "واحدة"
+		} else if c == Accusative {
+			one = "واحدًا"
+		}
+		stringOfNum = append(stringOfNum, singularInCase(noun, c), one)
+	case whole == 2:
+		stringOfNum = append(stringOfNum, dualInCase(noun.Dual, c))
+	case rest == 0:
+		stringOfNum = append(stringOfNum, spellGroups(groups, false, opts, true), noun.Singular)
+	case rest <= 2:
+		//Ones and twos after the hundreds and the scales repeat the noun, e.g. مئة كتاب و كتابان
+		higher := append([]int{groups[0] - rest}, groups[1:]...)
+		counted := singularInCase(noun, c)
+		if rest == 2 {
+			counted = dualInCase(noun.Dual, c)
+		}
+		stringOfNum = append(stringOfNum, spellGroups(higher, false, opts, true), noun.Singular, "و", counted)
+	case rest <= 10:
+		stringOfNum = append(stringOfNum, spellGroups(groups, false, opts, false), noun.Plural)
+	default:
+		stringOfNum = append(stringOfNum, spellGroups(groups, false, opts, false), noun.Accusative)
+	}
+	return strings.Join(stringOfNum, " ")
+}
+
+//singularInCase returns the singular of the noun in the grammatical case
+func singularInCase(noun Noun, c Case) string {
+	if c == Accusative {
+		return noun.Accusative
+	}
+	return noun.Singular
+}
+
+//dualInCase returns a dual and its adjectives in the grammatical case
+func dualInCase(dual string, c Case) string {
+	words := strings.Fields(dual)
+	for i, word := range words {
+		words[i] = inCase(word, c)
+	}
+	return strings.Join(words, " ")
+}
+
+//inCase returns a number word or a dual in the grammatical case, duals and tens end with ين
+//instead of ان and ون in the accusative and genitive
+func inCase(word string, c Case) string {
+	if c == Nominative {
+		return word
+	}
+	if form, ok := _obliqueForms[word]; ok {
+		return form
+	}
+	for _, ending := range []string{"ان", "ون"} {
+		if strings.HasSuffix(word, ending) {
+			return strings.TrimSuffix(word, ending) + "ين"
+		}
+	}
+	return word
+}
+
+//ErrNotOrdinal is returned when an ordinal is requested for a number lower than one
+var ErrNotOrdinal = errors.New("garabic: ordinals start at one")
+
+//Ordinal numbers in Arabic, الأول and الأولى become الحادي and الحادية with the tens
+var _ordinals = []string{
+	"", "أول", "ثاني", "ثالث", "رابع", "خامس", "سادس", "سابع", "ثامن", "تاسع", "عاشر",
+}
+
+var _ordinalsFeminine = []string{
+	"", "أولى", "ثانية", "ثالثة", "رابعة", "خامسة", "سادسة", "سابعة", "ثامنة", "تاسعة", "عاشرة",
+}
+
+//OrdinalOptions controls the forms of the spelled ordinals
+type OrdinalOptions struct {
+	//Feminine spells the ordinal of a feminine noun, e.g. الصفحة الأولى instead of الفصل الأول
+	Feminine bool
+	//Definite adds the article, e.g. الخامس و العشرون instead of خامس و عشرون
+	Definite bool
+	//Case is the grammatical case of the ordinal, it changes the tens and the tanween of indefinite ordinals
+	Case Case
+}
+
+//SpellOrdinal will transform a number into a readable arabic ordinal, e.g. الحادي عشر.
+//The hundreds and higher scales are counted after the ordinal of the last two digits,
+//e.g. الخامس و العشرون بعد المئة. ErrNotOrdinal is returned for numbers lower than one
+func SpellOrdinal(input int, opts OrdinalOptions) (string, error) {
+	if input < 1 {
+		return "", ErrNotOrdinal
+	}
+	rest, scales := input%100, input-input%100
+	if rest == 0 {
+		return withArticle(spellInt64(int64(scales), SpellOptions{Case: opts.Case}), opts.Definite), nil
+	}
+	ordinal := spellOrdinalTens(rest, rest > 10, opts)
+	if scales > 0 {
+		ordinal = fmt.Sprintf("%s بعد %s", ordinal, withArticle(spellInt64(int64(scales), SpellOptions{Case: Genitive}), opts.Definite))
+	}
+	return ordinal, nil
+}
+
+//spellOrdinalTens spells the ordinal of a number between 1 and 99, units come before tens.
+//One is الحادي in compound ordinals
+func spellOrdinalTens(number int, compound bool, opts OrdinalOptions) string {
+	units, ten := _ordinals, "عشر"
+	if opts.Feminine {
+		units, ten = _ordinalsFeminine, "عشرة"
+	}
+	unit := units[number%10]
+	if number%10 == 1 && compound {
+		unit = "حادي"
+		if opts.Feminine {
+			unit = "حادية"
+		}
+	}
+
+	switch tens := number / 10; {
+	case number == 10:
+		return ordinalInCase(units[10], opts)
+	case tens == 0:
+		return ordinalInCase(unit, opts)
+	case tens == 1:
+		//Compound ordinals from eleven to nineteen are indeclinable
+		return fmt.Sprintf("%s %s", withArticle(unit, opts.Definite), ten)
+	case number%10 == 0:
+		return withArticle(inCase(_tens[tens], opts.Case), opts.Definite)
+	default:
+		return fmt.Sprintf("%s و %s", ordinalInCase(unit, opts), withArticle(inCase(_tens[tens], opts.Case), opts.Definite))
+	}
+}
+
+//ordinalInCase returns an ordinal word in the grammatical case with the article if it's definite,
+//indefinite ordinals take the tanween, e.g. ثالثًا and ثالثةً in the accusative and ثانٍ otherwise
+func ordinalInCase(word string, opts OrdinalOptions) string {
+	switch {
+	case opts.Definite:
+		return withArticle(word, true)
+	case opts.Feminine && opts.Case == Accusative && strings.HasSuffix(word, "ة"):
+		return word + "ً"
+	case opts.Feminine:
+		return word
+	case opts.Case == Accusative:
+		return word + "ًا"
+	case strings.HasSuffix(word, "ي"):
+		return strings.TrimSuffix(word, "ي") + "ٍ"
+	default:
+		return word
+	}
+}
+
+//withArticle adds the definite article to the first word of the text
+func withArticle(text string, definite bool) string {
+	if !definite {
+		return text
+	}
+	return "ال" + text
+}
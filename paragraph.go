@@ -0,0 +1,118 @@
+package garabic
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+//ShapeParagraph breaks a right to left text into lines no wider than maxWidth, then shapes each line
+//and reorders it visually, explicit newlines start a new paragraph
+func ShapeParagraph(text string, maxWidth int, measure func(string) int) []string {
+	return ShapeParagraphWithDirection(text, maxWidth, measure, RightToLeft)
+}
+
+//ShapeParagraphWithDirection breaks the text into lines no wider than maxWidth in logical order, then shapes
+//each line and reorders it visually with the embedding levels resolved for its whole paragraph, so a line
+//keeps the direction of the paragraph it belongs to. measure returns the width of shaped text in logical order,
+//e.g. the advance of a font face, characters are counted when it is nil. Lines are broken at spaces,
+//a word wider than maxWidth is kept on its own line
+func ShapeParagraphWithDirection(text string, maxWidth int, measure func(string) int, dir Direction) []string {
+	var lines []string
+	for _, line := range shapeLines(text, maxWidth, measure, dir) {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+//shapedLine is the line between the runes start and end of a shaped paragraph
+type shapedLine struct {
+	paragraph  *bidiParagraph
+	letters    []shapedLetter
+	start, end int
+}
+
+//String returns the runes of the line in visual order
+func (l shapedLine) String() string {
+	visual := make([]rune, 0, l.end-l.start)
+	for _, i := range l.paragraph.visualOrder(l.start, l.end) {
+		visual = append(visual, l.paragraph.visualRune(i))
+	}
+	return string(visual)
+}
+
+//shapeLines shapes the paragraphs of the text and breaks them into lines no wider than maxWidth,
+//the offsets of the shaped letters are byte offsets in the text
+func shapeLines(text string, maxWidth int, measure func(string) int, dir Direction) []shapedLine {
+	if measure == nil {
+		measure = countCharacters
+	}
+	var lines []shapedLine
+	for start := 0; start <= len(text); {
+		end := start
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if bidiClass(r) == bidi.B {
+				break
+			}
+			end += size
+		}
+
+		letters := shapeLetters(text[start:end])
+		shaped := make([]rune, len(letters))
+		for i := range letters {
+			shaped[i] = letters[i].shape
+			letters[i].start += start
+			letters[i].end += start
+			if letters[i].split > 0 {
+				letters[i].split += start
+			}
+		}
+		paragraph := newBidiParagraph(shaped, dir)
+		for _, line := range breakLines(shaped, maxWidth, measure) {
+			lines = append(lines, shapedLine{paragraph: paragraph, letters: letters, start: line[0], end: line[1]})
+		}
+
+		//CR LF is a single paragraph separator
+		separator := 1
+		if end < len(text) {
+			_, separator = utf8.DecodeRuneInString(text[end:])
+			if text[end] == '\r' && end+1 < len(text) && text[end+1] == '\n' {
+				separator++
+			}
+		}
+		start = end + separator
+	}
+	return lines
+}
+
+//breakLines returns the [start, end) ranges of the lines of a shaped paragraph, the spaces between
+//two lines are dropped
+func breakLines(shaped []rune, maxWidth int, measure func(string) int) [][2]int {
+	var lines [][2]int
+	start, end := 0, 0
+	for end < len(shaped) {
+		next := end
+		for next < len(shaped) && unicode.IsSpace(shaped[next]) {
+			next++
+		}
+		//Trailing spaces of the paragraph stay on its last line
+		if next == len(shaped) {
+			end = next
+			break
+		}
+		for next < len(shaped) && !unicode.IsSpace(shaped[next]) {
+			next++
+		}
+		if end > start && measure(string(shaped[start:next])) > maxWidth {
+			lines = append(lines, [2]int{start, end})
+			for start = end; start < len(shaped) && unicode.IsSpace(shaped[start]); start++ {
+			}
+			end = start
+			continue
+		}
+		end = next
+	}
+	return append(lines, [2]int{start, end})
+}
@@ -0,0 +1,343 @@
+package garabic
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//ParseError reports a word that can't be read as part of a number
+type ParseError struct {
+	//Offset is the byte offset of the word in the input
+	Offset int
+	//Word is the unexpected word as written in the input, it's empty when the number is missing
+	Word string
+}
+
+func (e *ParseError) Error() string {
+	if e.Word == "" {
+		return fmt.Sprintf("garabic: missing number at offset %d", e.Offset)
+	}
+	return fmt.Sprintf("garabic: unexpected %q at offset %d", e.Word, e.Offset)
+}
+
+//numberWordKind is the role of a word in a spelled number
+type numberWordKind int
+
+const (
+	//unitWord adds its value, it multiplies a following مئة
+	unitWord numberWordKind = iota + 1
+	//tensWord adds its value, e.g. عشر in أحد عشر or عشرون
+	tensWord
+	//hundredWord adds its value, a bare مئة multiplies the unit before it
+	hundredWord
+	//scaleWord multiplies the number before it, or counts one or two when it's alone
+	scaleWord
+	//conjunctionWord joins the parts of the number
+	conjunctionWord
+	//minusWord starts a negative number
+	minusWord
+)
+
+//numberWord is a normalized word of a spelled number
+type numberWord struct {
+	kind numberWordKind
+	//value is the value of the word, the power of 1000 of a scale word
+	value int64
+	//dual marks the duals of the scale words, e.g. ألفان
+	dual bool
+	//digits is the exact value of a number in digits, its value is capped to math.MaxInt64
+	digits *big.Int
+}
+
+//_numberWords are the normalized words of the spelled numbers with their spelling variants
+var _numberWords = buildNumberWords()
+
+//buildNumberWords normalizes the words of the number tables in the nominative and oblique cases
+func buildNumberWords() map[string]numberWord {
+	words := map[string]numberWord{
+		"و":    {kind: conjunctionWord},
+		"سالب": {kind: minusWord},
+		"ناقص": {kind: minusWord},
+		//ثمان is the form of ثمانية before مئة when written apart
+		"ثمان": {kind: unitWord, value: 8},
+	}
+	add := func(word string, entry numberWord) {
+		for _, c := range []Case{Nominative, Accusative} {
+			form := Normalize(inCase(word, c))
+			words[form] = entry
+			//مائة is the classical spelling of مئة
+			words[strings.Replace(form, "مئ", "مائ", 1)] = entry
+		}
+	}
+
+	for i := range _zeroToNine {
+		add(_zeroToNine[i], numberWord{kind: unitWord, value: int64(i)})
+		add(_zeroToNineFeminine[i], numberWord{kind: unitWord, value: int64(i)})
+	}
+	add(_elevenToNineteen[0], numberWord{kind: tensWord, value: 10})
+	add(_elevenToNineteenFeminine[0], numberWord{kind: tensWord, value: 10})
+	for i := 1; i < len(_elevenToNineteen); i++ {
+		//The teens are read as a unit followed by عشر
+		for _, teen := range []string{_elevenToNineteen[i], _elevenToNineteenFeminine[i]} {
+			add(strings.Fields(teen)[0], numberWord{kind: unitWord, value: int64(i)})
+			add(strings.Fields(inCase(teen, Accusative))[0], numberWord{kind: unitWord, value: int64(i)})
+		}
+	}
+	for i := 2; i < len(_tens); i++ {
+		add(_tens[i], numberWord{kind: tensWord, value: int64(i * 10)})
+	}
+	for i := 1; i < len(_hundreds); i++ {
+		add(_hundreds[i], numberWord{kind: hundredWord, value: int64(i * 100)})
+		add(_hundredsConstruct[i], numberWord{kind: hundredWord, value: int64(i * 100)})
+	}
+
+	for i := 1; i < len(_scaleNumbers); i++ {
+		scale := _scaleNumbers[i]
+		for _, form := range []string{scale.Singular, scale.Plural, scale.Accusative} {
+			add(form, numberWord{kind: scaleWord, value: int64(i)})
+		}
+		//Duals in construct lose their ن, e.g. ألفا ريال, they're added last as ألفا is also the accusative without tanwin
+		for _, form := range []string{scale.Dual, strings.TrimSuffix(scale.Dual, "ن"), strings.TrimSuffix(inCase(scale.Dual, Accusative), "ن")} {
+			add(form, numberWord{kind: scaleWord, value: int64(i), dual: true})
+		}
+	}
+	return words
+}
+
+//numberToken is a word of a spelled number, start and end are its byte offsets in the input
+type numberToken struct {
+	text       string
+	start, end int
+}
+
+//ParseNumber reads a number spelled in arabic words like the output of SpellNumber back into an integer,
+//e.g. ألف ومئتان وخمسون is read as 1250. The words are normalized so hamzas, harakat and spelling
+//variants like مائة are accepted, و can be attached to the next word, and digits can be mixed with words,
+//e.g. 3 ملايين. A *ParseError with the offset of the first unexpected word is returned on failure, words out
+//of order like خمسة خمسة or ألف مليون are unexpected,
+//ErrNumberTooLarge is returned when the number doesn't fit in an int64
+func ParseNumber(input string) (int64, error) {
+	tokens := numberTokens(input)
+	value, read := parseNumberTokens(tokens)
+	if read < len(tokens) || read == 0 {
+		return 0, unexpectedToken(tokens, read, len(input))
+	}
+	if !value.IsInt64() {
+		return 0, ErrNumberTooLarge
+	}
+	return value.Int64(), nil
+}
+
+//unexpectedToken returns the error of the first token that can't continue the number. A و after the number
+//is skipped to report the word following it, or the first trailing و. The error is at the end of the input
+//when there are no tokens left
+func unexpectedToken(tokens []numberToken, read int, end int) error {
+	i := read
+	if read == 0 && len(tokens) > 0 {
+		if word, _ := lookupNumberWord(tokens[0].text); word.kind == minusWord {
+			i = 1
+		}
+	}
+	for j := i; read > 0 && j < len(tokens); j++ {
+		if word, _ := lookupNumberWord(tokens[j].text); word.kind != conjunctionWord {
+			i = j
+			break
+		}
+	}
+	if i < len(tokens) {
+		return &ParseError{Offset: tokens[i].start, Word: tokens[i].text}
+	}
+	return &ParseError{Offset: end}
+}
+
+//numberTokens splits the input into normalized words at spaces, with the offsets of the words
+//in the input. A و attached to a word that isn't a number word is split from it
+func numberTokens(input string) []numberToken {
+	normalized, alignment := NormalizeAligned(input)
+	var tokens []numberToken
+	appendToken := func(start, end int) {
+		origStart, origEnd := alignment.Original(start, end)
+		tokens = append(tokens, numberToken{text: input[origStart:origEnd], start: origStart, end: origEnd})
+	}
+
+	for start := 0; start < len(normalized); {
+		r, size := utf8.DecodeRuneInString(normalized[start:])
+		if unicode.IsSpace(r) {
+			start += size
+			continue
+		}
+		end := start
+		for end < len(normalized) {
+			r, size := utf8.DecodeRuneInString(normalized[end:])
+			if unicode.IsSpace(r) {
+				break
+			}
+			end += size
+		}
+		word := normalized[start:end]
+		if _, ok := _numberWords[word]; !ok && len(word) > len("و") && strings.HasPrefix(word, "و") {
+			appendToken(start, start+len("و"))
+			start += len("و")
+		}
+		appendToken(start, end)
+		start = end
+	}
+	return tokens
+}
+
+//parseNumberTokens reads the longest number at the start of the tokens and returns the number of tokens read,
+//no tokens are read when the tokens don't start with a number. The number stops before the first word that
+//can't follow the words before it:
+// the parts of a group of three digits are joined by و, hundreds come first and each part is read once
+// a unit is joined without و to عشر in the teens and to مئة, e.g. ثلاثة عشر and ثلاث مئة
+// a scale word follows the group it counts without و, or counts one or two alone, e.g. ألف and ألفان
+// the scale words decrease along the number, e.g. مليون و ألف, a scale counting hundreds alone can be
+// repeated for one or two, e.g. مئة ألف و ألف
+func parseNumberTokens(tokens []numberToken) (*big.Int, int) {
+	total, current := new(big.Int), new(big.Int)
+	negative := false
+	//lastScale is the scale of the previous group, the first group can have any scale
+	lastScale := int64(len(_scaleNumbers))
+	//repeat is set after a group of hundreds alone, its scale can be repeated for one or two, e.g. مئة ألف و ألف
+	repeat := false
+	//filled holds the parts of the current group already read, prev is the kind of the previous word
+	//and unit the value of the previous word when it's a unit
+	filled := map[numberWordKind]bool{}
+	prev, unit := numberWordKind(0), int64(0)
+	//joined is set at the start of the number and after و, when a new part can start
+	joined := true
+	read := 0
+loop:
+	for i, token := range tokens {
+		word, ok := lookupNumberWord(token.text)
+		if !ok {
+			break
+		}
+		//compound is set when a unit is joined to عشر or مئة after it
+		compound := prev == unitWord && unit > 0 && unit < 10
+		switch word.kind {
+		case minusWord:
+			if i > 0 {
+				break loop
+			}
+			negative = true
+			continue
+		case conjunctionWord:
+			if joined {
+				break loop
+			}
+			joined = true
+			prev = conjunctionWord
+			continue
+		case unitWord:
+			if !joined || filled[unitWord] {
+				break loop
+			}
+			if word.digits != nil {
+				current.Add(current, word.digits)
+			} else {
+				current.Add(current, big.NewInt(word.value))
+			}
+			//Numbers in digits fill the parts of their digits
+			filled[unitWord] = true
+			if word.value >= 10 {
+				filled[tensWord] = true
+			}
+			if word.value >= 100 {
+				filled[hundredWord] = true
+			}
+			unit = word.value
+		case tensWord:
+			if filled[tensWord] || !joined && !(compound && word.value == 10) {
+				break loop
+			}
+			current.Add(current, big.NewInt(word.value))
+			filled[tensWord] = true
+		case hundredWord:
+			switch {
+			case compound && word.value == 100 && !filled[tensWord] && !filled[hundredWord]:
+				//ثلاث مئة is read like ثلاثمئة, the unit is part of the hundreds
+				current.Add(current, big.NewInt(unit*99))
+				delete(filled, unitWord)
+			case joined && len(filled) == 0:
+				current.Add(current, big.NewInt(word.value))
+			default:
+				break loop
+			}
+			filled[hundredWord] = true
+		case scaleWord:
+			counted := len(filled) > 0
+			repeated := word.value == lastScale && repeat && !counted
+			if word.value > lastScale || word.value == lastScale && !repeated || counted == joined {
+				break loop
+			}
+			scale := new(big.Int).Exp(big.NewInt(1000), big.NewInt(word.value), nil)
+			switch {
+			case counted:
+				//Accusatives without tanwin like ألفا read like duals, they multiply the number before them
+				scale.Mul(scale, current)
+			case word.dual:
+				scale.Mul(scale, big.NewInt(2))
+			}
+			total.Add(total, scale)
+			current.SetInt64(0)
+			repeat = counted && len(filled) == 1 && filled[hundredWord]
+			filled = map[numberWordKind]bool{}
+			lastScale = word.value
+		}
+		prev, joined = word.kind, false
+		read = i + 1
+	}
+	if read == 0 {
+		return nil, 0
+	}
+
+	total.Add(total, current)
+	if negative {
+		total.Neg(total)
+	}
+	return total, read
+}
+
+//lookupNumberWord returns the role of a token in a spelled number, digits are read as units
+func lookupNumberWord(token string) (numberWord, bool) {
+	if digits, ok := parseDigits(token); ok {
+		value := int64(math.MaxInt64)
+		if digits.IsInt64() {
+			value = digits.Int64()
+		}
+		return numberWord{kind: unitWord, value: value, digits: digits}, true
+	}
+	word, ok := _numberWords[Normalize(token)]
+	return word, ok
+}
+
+//parseDigits reads a token of western, arabic-indic or extended arabic-indic digits
+func parseDigits(token string) (*big.Int, bool) {
+	if token == "" {
+		return nil, false
+	}
+	var digits strings.Builder
+	for _, r := range token {
+		digit, ok := digitValue(r)
+		if !ok {
+			return nil, false
+		}
+		digits.WriteByte(byte('0' + digit))
+	}
+	return new(big.Int).SetString(digits.String(), 10)
+}
+
+//digitValue returns the value of a western, arabic-indic or extended arabic-indic digit
+func digitValue(r rune) (int, bool) {
+	for _, zero := range []rune{'0', '٠', '۰'} {
+		if r >= zero && r <= zero+9 {
+			return int(r - zero), true
+		}
+	}
+	return 0, false
+}
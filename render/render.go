@@ -0,0 +1,229 @@
+//Package render draws arabic and mixed text on images with a font.Face,
+//the text is shaped with garabic, wrapped to the width of a rectangle and right aligned by default
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"unicode"
+
+	"github.com/abdullahdiaa/garabic"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+//Align is the horizontal alignment of the lines in the rectangle
+type Align int
+
+const (
+	//AlignRight aligns the lines to the right edge of the rectangle, the default for arabic text
+	AlignRight Align = iota
+	//AlignLeft aligns the lines to the left edge of the rectangle
+	AlignLeft
+	//AlignCenter centers the lines in the rectangle
+	AlignCenter
+)
+
+//Options controls how the text is drawn, the zero value draws black right aligned text
+type Options struct {
+	//Color of the text, black if nil
+	Color color.Color
+	//LineHeight is the distance between the baselines of two lines, the height of the face if zero
+	LineHeight fixed.Int26_6
+	//Align of the lines in the rectangle
+	Align Align
+}
+
+//Draw shapes the text, wraps it to the width of r and draws it aligned inside r on dst, glyphs are clipped to r.
+//Harakat are drawn over the letters they follow in logical order, stacked when a letter has several of them.
+//It returns the bounds of the drawn lines, which go beyond r when the text doesn't fit in it
+func Draw(dst draw.Image, face font.Face, r image.Rectangle, text string, opts *Options) image.Rectangle {
+	if opts == nil {
+		opts = &Options{}
+	}
+	var src image.Image = image.Black
+	if opts.Color != nil {
+		src = image.NewUniform(opts.Color)
+	}
+	clip := r.Intersect(dst.Bounds())
+	return layout(face, r, text, opts, func(c rune, dot fixed.Point26_6) {
+		dr, mask, maskp, _, ok := face.Glyph(dot, c)
+		if !ok {
+			return
+		}
+		clipped := dr.Intersect(clip)
+		if clipped.Empty() {
+			return
+		}
+		draw.DrawMask(dst, clipped, src, image.Point{}, mask, maskp.Add(clipped.Min.Sub(dr.Min)), draw.Over)
+	})
+}
+
+//Measure returns the bounds Draw would return without drawing the text
+func Measure(face font.Face, r image.Rectangle, text string, opts *Options) image.Rectangle {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return layout(face, r, text, opts, nil)
+}
+
+//layout places the lines of the text in r and calls draw with the position of every glyph, if draw isn't nil
+func layout(face font.Face, r image.Rectangle, text string, opts *Options, draw func(c rune, dot fixed.Point26_6)) image.Rectangle {
+	metrics := face.Metrics()
+	lineHeight := opts.LineHeight
+	if lineHeight == 0 {
+		lineHeight = metrics.Height
+	}
+
+	var bounds image.Rectangle
+	baseline := fixed.I(r.Min.Y) + metrics.Ascent
+	for _, line := range wrap(face, text, fixed.I(r.Dx())) {
+		width := lineAdvance(face, line)
+		var x fixed.Int26_6
+		switch opts.Align {
+		case AlignLeft:
+			x = fixed.I(r.Min.X)
+		case AlignCenter:
+			x = fixed.I(r.Min.X) + (fixed.I(r.Dx())-width)/2
+		default:
+			x = fixed.I(r.Max.X) - width
+		}
+		if draw != nil {
+			drawLine(face, line, fixed.Point26_6{X: x, Y: baseline}, draw)
+		}
+		bounds = bounds.Union(image.Rect(x.Floor(), (baseline - metrics.Ascent).Floor(), (x + width).Ceil(), (baseline + metrics.Descent).Ceil()))
+		baseline += lineHeight
+	}
+	return bounds
+}
+
+//wrap breaks the text into lines of shaped clusters no wider than width, explicit newlines start a new line.
+//Words wider than width are kept on their own line
+func wrap(face font.Face, text string, width fixed.Int26_6) [][]garabic.Cluster {
+	return garabic.ShapeParagraphClusters(text, int(width), func(s string) int {
+		return int(advance(face, s))
+	}, garabic.RightToLeft)
+}
+
+//advance returns the width of shaped text, marks take no space
+func advance(face font.Face, text string) fixed.Int26_6 {
+	var width fixed.Int26_6
+	previous := rune(-1)
+	for _, c := range text {
+		if isMark(c) {
+			continue
+		}
+		if previous >= 0 {
+			width += face.Kern(previous, c)
+		}
+		a, _ := face.GlyphAdvance(c)
+		width += a
+		previous = c
+	}
+	return width
+}
+
+//lineAdvance returns the width of a line of clusters
+func lineAdvance(face font.Face, line []garabic.Cluster) fixed.Int26_6 {
+	glyphs := make([]rune, len(line))
+	for i, cluster := range line {
+		glyphs[i] = cluster.Glyph
+	}
+	return advance(face, string(glyphs))
+}
+
+//drawLine calls draw with the position of every glyph of a line of clusters starting at dot
+func drawLine(face font.Face, line []garabic.Cluster, dot fixed.Point26_6, draw func(c rune, dot fixed.Point26_6)) {
+	previous := rune(-1)
+	for _, cluster := range line {
+		var a fixed.Int26_6
+		if !isMark(cluster.Glyph) {
+			if previous >= 0 {
+				dot.X += face.Kern(previous, cluster.Glyph)
+			}
+			a, _ = face.GlyphAdvance(cluster.Glyph)
+			previous = cluster.Glyph
+		}
+		draw(cluster.Glyph, dot)
+		drawMarks(face, cluster, dot, a, draw)
+		dot.X += a
+	}
+}
+
+//markStack is the space taken by the marks drawn over a letter, relative to the baseline
+type markStack struct {
+	top, bottom fixed.Int26_6
+	above       bool
+	below       bool
+}
+
+//drawMarks draws the marks of a cluster whose glyph is drawn at dot. Arabic harakat are centered over
+//their letter, or over their half of a lam alef ligature, and stacked clear of the letter with shadda closest to it.
+//Other marks are left to the font after their letter
+func drawMarks(face font.Face, cluster garabic.Cluster, dot fixed.Point26_6, glyphAdvance fixed.Int26_6, draw func(c rune, dot fixed.Point26_6)) {
+	//The lam is the right half of a lam alef ligature and the alef its left half
+	ligature := cluster.Glyph >= '\uFEF5' && cluster.Glyph <= '\uFEFC'
+	//Marks are kept clear of the letter
+	var stacks [2]markStack
+	if bounds, _, ok := face.GlyphBounds(cluster.Glyph); ok && !isMark(cluster.Glyph) {
+		stacks[0] = markStack{top: bounds.Min.Y, bottom: bounds.Max.Y, above: true, below: true}
+		stacks[1] = stacks[0]
+	}
+	for _, pass := range []bool{true, false} {
+		for i, mark := range cluster.Marks {
+			if !garabic.IsArabicLetter(mark) {
+				if pass {
+					draw(mark, fixed.Point26_6{X: dot.X + glyphAdvance, Y: dot.Y})
+				}
+				continue
+			}
+			//Shadda is drawn in the first pass, the other harakat over it in the second
+			if (mark == shadda) != pass {
+				continue
+			}
+			center, stack := glyphAdvance/2, &stacks[0]
+			if ligature && i < cluster.LamMarks {
+				center = glyphAdvance * 3 / 4
+			} else if ligature {
+				center, stack = glyphAdvance/4, &stacks[1]
+			}
+			draw(mark, stack.place(face, mark, dot, center))
+		}
+	}
+}
+
+//shadda => ّ
+const shadda = '\u0651'
+
+//markGap is the space between two stacked marks
+const markGap = fixed.Int26_6(1 << 6)
+
+//place returns the position of a mark centered at center over a letter drawn at dot,
+//the mark is moved away from the letter if the stack already has marks on its side
+func (s *markStack) place(face font.Face, mark rune, dot fixed.Point26_6, center fixed.Int26_6) fixed.Point26_6 {
+	bounds, _, ok := face.GlyphBounds(mark)
+	if !ok {
+		return dot
+	}
+	dot.X += center - (bounds.Min.X+bounds.Max.X)/2
+	var shift fixed.Int26_6
+	if bounds.Min.Y+bounds.Max.Y < 0 {
+		if s.above && bounds.Max.Y > s.top-markGap {
+			shift = s.top - markGap - bounds.Max.Y
+		}
+		s.top, s.above = bounds.Min.Y+shift, true
+	} else {
+		if s.below && bounds.Min.Y < s.bottom+markGap {
+			shift = s.bottom + markGap - bounds.Min.Y
+		}
+		s.bottom, s.below = bounds.Max.Y+shift, true
+	}
+	dot.Y += shift
+	return dot
+}
+
+//isMark checks if the rune is a combining mark drawn over another letter
+func isMark(c rune) bool {
+	return unicode.In(c, unicode.Mn, unicode.Me)
+}
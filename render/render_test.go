@@ -0,0 +1,196 @@
+package render
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+)
+
+const succeed = "✅"
+const failed = "❌"
+
+var update = flag.Bool("update", false, "update the golden images in test_data")
+
+//renderTestCases contains the texts drawn and compared against the golden images of test_data
+var renderTestCases = []struct {
+	description string
+	golden      string
+	input       string
+	rect        image.Rectangle
+	opts        *Options
+}{
+	{
+		"Drawing 1 line right aligned",
+		"line.png",
+		"السلام عليكم",
+		image.Rect(10, 10, 290, 50),
+		nil,
+	},
+	{
+		"Drawing wrapped paragraph with tashkeel",
+		"wrapped.png",
+		"قِفا نَبكِ مِن ذِكرى حَبيبٍ وَمَنزِلِ بِسِقطِ اللِوى بَينَ الدَخولِ فَحَومَلِ",
+		image.Rect(10, 10, 290, 190),
+		nil,
+	},
+	{
+		"Drawing mixed text with english words and numbers",
+		"mixed.png",
+		"المصفوفة (Multidimentional Array) هي 12.5 ريال",
+		image.Rect(10, 10, 290, 190),
+		&Options{Color: color.RGBA{120, 157, 243, 255}},
+	},
+	{
+		"Drawing lines centered with explicit newlines",
+		"centered.png",
+		"بسم الله\nالرحمن الرحيم",
+		image.Rect(10, 10, 290, 190),
+		&Options{Align: AlignCenter, LineHeight: 40 << 6},
+	},
+	{
+		"Drawing stacked harakat and harakat on lam alef",
+		"harakat.png",
+		"كِتَـــابٌ\nمُحَمَّدٌ لَا إِلَٰهَ إِلَّا اللَّهُ",
+		image.Rect(10, 10, 290, 190),
+		&Options{LineHeight: 48 << 6},
+	},
+	{
+		"Drawing lines left aligned and clipped",
+		"clipped.png",
+		"نص عربي طويل لا يتسع في المستطيل المحدد له",
+		image.Rect(10, 10, 150, 60),
+		&Options{Align: AlignLeft},
+	},
+}
+
+//loadFace loads the bundled DejaVu Sans font
+func loadFace(t *testing.T) font.Face {
+	b, err := ioutil.ReadFile("test_data/DejaVuSans.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttf, err := opentype.Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face, err := opentype.NewFace(ttf, &opentype.FaceOptions{Size: 24, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return face
+}
+
+//TestDraw ...
+func TestDraw(t *testing.T) {
+	face := loadFace(t)
+	t.Log("Given an arabic text, it should be drawn shaped, wrapped and aligned like the golden image")
+	{
+		for i, tt := range renderTestCases {
+			img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+			draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+			Draw(img, face, tt.rect, tt.input, tt.opts)
+
+			path := filepath.Join("test_data", tt.golden)
+			t.Logf("\tTest: %d\t Drawing: %s", i, tt.input)
+			if *update {
+				writePNG(t, path, img)
+			}
+			if golden := readPNG(t, path); !samePixels(img, golden) {
+				t.Errorf("\t%s\t(%s)\tShould be drawn like %s", failed, tt.description, path)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be drawn like %s", succeed, tt.description, path)
+			}
+		}
+	}
+}
+
+//TestMeasure ...
+func TestMeasure(t *testing.T) {
+	face := loadFace(t)
+	t.Log("Given an arabic text, the measured bounds should match the drawn lines")
+	{
+		for i, tt := range renderTestCases {
+			img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+			drawn := Draw(img, face, tt.rect, tt.input, tt.opts)
+			measured := Measure(face, tt.rect, tt.input, tt.opts)
+			t.Logf("\tTest: %d\t Measuring: %s", i, tt.input)
+			if drawn != measured {
+				t.Errorf("\t%s\t(%s)\tShould be measured as drawn %v, got %v instead", failed, tt.description, drawn, measured)
+			} else {
+				t.Logf("\t%s\t(%s)\tShould be measured as drawn %v", succeed, tt.description, drawn)
+			}
+		}
+	}
+
+	t.Log("Given a right aligned line, its bounds should end at the right edge of the rectangle")
+	{
+		rect := image.Rect(10, 10, 290, 50)
+		bounds := Measure(face, rect, "السلام عليكم", nil)
+		if bounds.Max.X != rect.Max.X || bounds.Min.X <= rect.Min.X || bounds.Min.Y != rect.Min.Y {
+			t.Errorf("\t%s\tShould be right aligned in %v, got %v instead", failed, rect, bounds)
+		} else {
+			t.Logf("\t%s\tShould be right aligned in %v", succeed, rect)
+		}
+	}
+
+	t.Log("Given a long text, it should be wrapped to the width of the rectangle")
+	{
+		rect := image.Rect(10, 10, 290, 190)
+		bounds := Measure(face, rect, renderTestCases[1].input, nil)
+		if lines := bounds.Dy() / face.Metrics().Height.Ceil(); lines < 2 || bounds.Dx() > rect.Dx() {
+			t.Errorf("\t%s\tShould be wrapped in %v, got %v instead", failed, rect, bounds)
+		} else {
+			t.Logf("\t%s\tShould be wrapped in %v", succeed, rect)
+		}
+	}
+}
+
+//readPNG decodes a golden image
+func readPNG(t *testing.T, path string) image.Image {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+//writePNG encodes a golden image
+func writePNG(t *testing.T, path string, img image.Image) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//samePixels compares the colors of two images
+func samePixels(a, b image.Image) bool {
+	if a.Bounds() != b.Bounds() {
+		return false
+	}
+	for y := a.Bounds().Min.Y; y < a.Bounds().Max.Y; y++ {
+		for x := a.Bounds().Min.X; x < a.Bounds().Max.X; x++ {
+			if color.RGBAModel.Convert(a.At(x, y)) != color.RGBAModel.Convert(b.At(x, y)) {
+				return false
+			}
+		}
+	}
+	return true
+}
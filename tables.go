@@ -0,0 +1,61 @@
+// Code generated by running "go generate" in github.com/abdullahdiaa/garabic. DO NOT EDIT.
+
+package garabic
+
+// joiningTypesVersion is the unicode version of ArabicShaping.txt used to generate the tables
+const joiningTypesVersion = "13.0.0"
+
+// joiningTypeRanges lists the joining types of arabic letters and join controls
+var joiningTypeRanges = []joiningRange{
+	{0x0600, 0x0605, nonJoining},
+	{0x0608, 0x0608, nonJoining},
+	{0x060B, 0x060B, nonJoining},
+	{0x0620, 0x0620, dualJoining},
+	{0x0621, 0x0621, nonJoining},
+	{0x0622, 0x0625, rightJoining},
+	{0x0626, 0x0626, dualJoining},
+	{0x0627, 0x0627, rightJoining},
+	{0x0628, 0x0628, dualJoining},
+	{0x0629, 0x0629, rightJoining},
+	{0x062A, 0x062E, dualJoining},
+	{0x062F, 0x0632, rightJoining},
+	{0x0633, 0x063F, dualJoining},
+	{0x0640, 0x0640, joinCausing},
+	{0x0641, 0x0647, dualJoining},
+	{0x0648, 0x0648, rightJoining},
+	{0x0649, 0x064A, dualJoining},
+	{0x066E, 0x066F, dualJoining},
+	{0x0671, 0x0673, rightJoining},
+	{0x0674, 0x0674, nonJoining},
+	{0x0675, 0x0677, rightJoining},
+	{0x0678, 0x0687, dualJoining},
+	{0x0688, 0x0699, rightJoining},
+	{0x069A, 0x06BF, dualJoining},
+	{0x06C0, 0x06C0, rightJoining},
+	{0x06C1, 0x06C2, dualJoining},
+	{0x06C3, 0x06CB, rightJoining},
+	{0x06CC, 0x06CC, dualJoining},
+	{0x06CD, 0x06CD, rightJoining},
+	{0x06CE, 0x06CE, dualJoining},
+	{0x06CF, 0x06CF, rightJoining},
+	{0x06D0, 0x06D1, dualJoining},
+	{0x06D2, 0x06D3, rightJoining},
+	{0x06D5, 0x06D5, rightJoining},
+	{0x06DD, 0x06DD, nonJoining},
+	{0x06EE, 0x06EF, rightJoining},
+	{0x06FA, 0x06FC, dualJoining},
+	{0x06FF, 0x06FF, dualJoining},
+	{0x0750, 0x0758, dualJoining},
+	{0x0759, 0x075B, rightJoining},
+	{0x075C, 0x076A, dualJoining},
+	{0x076B, 0x076C, rightJoining},
+	{0x076D, 0x0770, dualJoining},
+	{0x0771, 0x0771, rightJoining},
+	{0x0772, 0x0772, dualJoining},
+	{0x0773, 0x0774, rightJoining},
+	{0x0775, 0x0777, dualJoining},
+	{0x0778, 0x0779, rightJoining},
+	{0x077A, 0x077F, dualJoining},
+	{0x200C, 0x200C, nonJoining},
+	{0x200D, 0x200D, joinCausing},
+}
@@ -0,0 +1,120 @@
+package garabic
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+//ErrInvalidAmount is returned when an amount of money can't be read
+var ErrInvalidAmount = errors.New("garabic: invalid amount")
+
+//Currency holds the names of a currency and its subunit used to spell amounts of money
+type Currency struct {
+	//Name is the main unit, e.g. ريال سعودي
+	Name Noun
+	//Subunit is the fractional unit, e.g. هللة
+	Subunit Noun
+	//Ratio is the number of subunits in a unit, a power of ten like the 100 halalas of a riyal
+	Ratio int
+}
+
+//Currencies with their arabic names
+var (
+	//SAR is the Saudi riyal
+	SAR = Currency{
+		Name:    Noun{"ريال سعودي", "ريالان سعوديان", "ريالات سعودية", "ريالًا سعوديًا", false},
+		Subunit: Noun{"هللة", "هللتان", "هللات", "هللة", true},
+		Ratio:   100,
+	}
+	//AED is the UAE dirham
+	AED = Currency{
+		Name:    Noun{"درهم إماراتي", "درهمان إماراتيان", "دراهم إماراتية", "درهمًا إماراتيًا", false},
+		Subunit: Noun{"فلس", "فلسان", "فلوس", "فلسًا", false},
+		Ratio:   100,
+	}
+	//EGP is the Egyptian pound
+	EGP = Currency{
+		Name:    Noun{"جنيه مصري", "جنيهان مصريان", "جنيهات مصرية", "جنيهًا مصريًا", false},
+		Subunit: Noun{"قرش", "قرشان", "قروش", "قرشًا", false},
+		Ratio:   100,
+	}
+	//KWD is the Kuwaiti dinar
+	KWD = Currency{
+		Name:    Noun{"دينار كويتي", "ديناران كويتيان", "دنانير كويتية", "دينارًا كويتيًا", false},
+		Subunit: Noun{"فلس", "فلسان", "فلوس", "فلسًا", false},
+		Ratio:   1000,
+	}
+	//JOD is the Jordanian dinar
+	JOD = Currency{
+		Name:    Noun{"دينار أردني", "ديناران أردنيان", "دنانير أردنية", "دينارًا أردنيًا", false},
+		Subunit: Noun{"فلس", "فلسان", "فلوس", "فلسًا", false},
+		Ratio:   1000,
+	}
+	//USD is the US dollar
+	USD = Currency{
+		Name:    Noun{"دولار أمريكي", "دولاران أمريكيان", "دولارات أمريكية", "دولارًا أمريكيًا", false},
+		Subunit: Noun{"سنت", "سنتان", "سنتات", "سنتًا", false},
+		Ratio:   100,
+	}
+	//EUR is the euro
+	EUR = Currency{
+		Name:    Noun{"يورو", "يوروان", "يورو", "يورو", false},
+		Subunit: Noun{"سنت", "سنتان", "سنتات", "سنتًا", false},
+		Ratio:   100,
+	}
+)
+
+//SpellAmount spells an amount of money given in subunits (تفقيط) with the phrasing of the amounts
+//written on invoices and cheques, e.g. 125025 halalas are spelled as
+//فقط ألف و مئتان و خمسون ريالًا سعوديًا و خمس و عشرون هللة لا غير
+func SpellAmount(subunits int64, currency Currency) string {
+	negative := subunits < 0
+	magnitude := uint64(subunits)
+	if negative {
+		//The magnitude of math.MinInt64 only fits in an uint64
+		magnitude = uint64(-subunits)
+	}
+	ratio := uint64(maxInt(currency.Ratio, 1))
+	return spellAmount(magnitude/ratio, magnitude%ratio, negative, currency)
+}
+
+//SpellAmountString spells an amount of money written as a decimal number like SpellAmount, e.g. 1,250.25.
+//Western and arabic digits and separators are accepted, e.g. ١٬٢٥٠٫٢٥, thousands separators only between
+//groups of three digits. ErrInvalidAmount is returned when it isn't a number or has more decimals than the subunits
+func SpellAmountString(amount string, currency Currency) (string, error) {
+	number, ok := readFormatted(amount, LocaleGulf, false)
+	if !ok {
+		return "", ErrInvalidAmount
+	}
+	ratio := big.NewInt(int64(maxInt(currency.Ratio, 1)))
+	//Trailing zeros past the subunits don't change the amount, other decimals don't fit in a subunit
+	subunits := new(big.Rat).Mul(number, new(big.Rat).SetInt(ratio))
+	if !subunits.IsInt() {
+		return "", ErrInvalidAmount
+	}
+	total := new(big.Int).Abs(subunits.Num())
+	units, rest := new(big.Int).QuoRem(total, ratio, new(big.Int))
+	if !units.IsUint64() {
+		return "", ErrInvalidAmount
+	}
+	return spellAmount(units.Uint64(), rest.Uint64(), number.Sign() < 0, currency), nil
+}
+
+//spellAmount spells the units and subunits of an amount of money, the units are left out
+//when there are only subunits
+func spellAmount(units, subunits uint64, negative bool, currency Currency) string {
+	var parts []string
+	if units > 0 || subunits == 0 {
+		parts = append(parts, spellQuantity(digitGroups(units), false, currency.Name, Nominative))
+	}
+	if subunits > 0 {
+		parts = append(parts, spellQuantity(digitGroups(subunits), false, currency.Subunit, Nominative))
+	}
+	amount := strings.Join(parts, " و ")
+	if negative && (units > 0 || subunits > 0) {
+		amount = "سالب " + amount
+	}
+	return fmt.Sprintf("فقط %s لا غير", amount)
+}
@@ -0,0 +1,68 @@
+package garabic
+
+import (
+	"strings"
+	"unicode"
+)
+
+//TerminalOptions controls how ShapeForTerminalWithOptions lays out text for terminals
+type TerminalOptions struct {
+	//Direction is the base direction of each line, the zero value takes the direction of its first strong letter
+	Direction Direction
+	//KeepHarakat keeps the harakat and other combining marks in the output. text/tabwriter counts every rune
+	//as a cell, so columns holding harakat should be padded with DisplayWidth instead
+	KeepHarakat bool
+}
+
+//ShapeForTerminal shapes the text and reorders it visually line by line for terminals that don't shape
+//or reorder arabic text themselves, harakat are dropped so every rune of the output takes one cell
+func ShapeForTerminal(input string) string {
+	return ShapeForTerminalWithOptions(input, TerminalOptions{})
+}
+
+//ShapeForTerminalWithOptions shapes the text and reorders it visually for terminals. Each line is a separate
+//paragraph, and the cells between tabs are laid out on their own so columns written with text/tabwriter
+//keep their order. Kept harakat are written after the glyph they're drawn over, bidi and join controls
+//are dropped once applied
+func ShapeForTerminalWithOptions(input string, opts TerminalOptions) string {
+	var output strings.Builder
+	output.Grow(len(input))
+	for l, line := range strings.Split(input, "\n") {
+		if l > 0 {
+			output.WriteByte('\n')
+		}
+		//CR LF line endings are kept
+		cr := strings.HasSuffix(line, "\r")
+		for c, cell := range strings.Split(strings.TrimSuffix(line, "\r"), "\t") {
+			if c > 0 {
+				output.WriteByte('\t')
+			}
+			for _, cluster := range ShapeClustersWithDirection(cell, opts.Direction) {
+				if unicode.Is(unicode.Cf, cluster.Glyph) {
+					continue
+				}
+				output.WriteRune(cluster.Glyph)
+				if opts.KeepHarakat {
+					output.WriteString(string(cluster.Marks))
+				}
+			}
+		}
+		if cr {
+			output.WriteByte('\r')
+		}
+	}
+	return output.String()
+}
+
+//DisplayWidth returns the number of terminal cells taken by a line of shaped text, harakat and
+//other combining marks are drawn over the previous cell and format characters are not drawn
+func DisplayWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		if isMark(r) || unicode.In(r, unicode.Cf, unicode.Cc) {
+			continue
+		}
+		width++
+	}
+	return width
+}
@@ -0,0 +1,81 @@
+package garabic
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//Unshape converts shaped text in visual order, like the output of Shape or text extracted from PDFs,
+//back to logical arabic letters so it can be normalized and searched
+func Unshape(input string) string {
+	return UnshapeWithDirection(input, RightToLeft)
+}
+
+//UnshapeWithDirection converts shaped text laid out in the given base direction back to logical arabic letters
+func UnshapeWithDirection(input string, dir Direction) string {
+	return unshapeForms(reorder(input, dir))
+}
+
+//unshapeForms replaces arabic presentation forms and ligatures with the letters they represent
+func unshapeForms(input string) string {
+	var output strings.Builder
+	output.Grow(len(input))
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !isPresentationForm(r) {
+			output.WriteRune(r)
+			continue
+		}
+		if letter, ok := presentationFormLetters[r]; ok {
+			output.WriteRune(letter)
+			continue
+		}
+		//Ligatures and isolated harakat decompose to their letters, harakat are spaced in isolation
+		decomposed := norm.NFKC.String(string(r))
+		if r >= '\uFE70' && r <= '\uFE7F' || r >= '\uFC5E' && r <= '\uFC63' {
+			decomposed = strings.TrimPrefix(decomposed, " ")
+		}
+		//Harakat drawn on a lam alef ligature belong to the lam, e.g. لَا is shaped as ﻻ followed by the fathah
+		if letters := []rune(decomposed); len(letters) == 2 && letters[0] == Lam && isLamAlef(letters[1]) {
+			output.WriteRune(Lam)
+			for i+1 < len(runes) && isMark(runes[i+1]) {
+				i++
+				output.WriteRune(runes[i])
+			}
+			output.WriteRune(letters[1])
+			continue
+		}
+		output.WriteString(decomposed)
+	}
+	return output.String()
+}
+
+//isLamAlef checks if the letter is an alef variant drawn with a lam before it as a ligature
+func isLamAlef(letter rune) bool {
+	_, ok := lamAlefLigatures[letter]
+	return ok
+}
+
+//isPresentationForm checks if the rune is in Arabic Presentation Forms-A or Arabic Presentation Forms-B
+func isPresentationForm(r rune) bool {
+	return (r >= '\uFB50' && r <= '\uFDFF') || (r >= '\uFE70' && r <= '\uFEFF')
+}
+
+//presentationFormLetters maps the shapes of arabicAlphabetShapes back to their letters
+var presentationFormLetters = func() map[rune]rune {
+	letters := make(map[rune]rune, 4*len(arabicAlphabetShapes))
+	for letter, shapes := range arabicAlphabetShapes {
+		//Lam alef ligatures are decomposed into two letters
+		if isPresentationForm(letter) {
+			continue
+		}
+		for _, form := range []rune{shapes.Independent, shapes.Initial, shapes.Medial, shapes.Final} {
+			if form != letter {
+				letters[form] = letter
+			}
+		}
+	}
+	return letters
+}()